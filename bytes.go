@@ -0,0 +1,82 @@
+package config
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+var byteUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"pb":  1e15,
+	"ki":  1 << 10,
+	"kib": 1 << 10,
+	"mi":  1 << 20,
+	"mib": 1 << 20,
+	"gi":  1 << 30,
+	"gib": 1 << 30,
+	"ti":  1 << 40,
+	"tib": 1 << 40,
+	"pi":  1 << 50,
+	"pib": 1 << 50,
+}
+
+var binaryByteUnits = []string{"PiB", "TiB", "GiB", "MiB", "KiB"}
+
+// ParseBytes parses human-readable size, e.g. 512, 16MiB, 1.5GB.
+// SI units (kB, MB, GB, TB, PB) are powers of 1000,
+// IEC units (KiB, MiB, GiB, TiB, PiB or Ki, Mi, Gi, Ti, Pi) are powers of 1024.
+func ParseBytes(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return r != '.' && !unicode.IsDigit(r)
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	num, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+
+	multiplier, ok := byteUnits[unit]
+	if !ok {
+		return 0, errors.Errorf("invalid size %q: unknown unit %q", s, s[i:])
+	}
+
+	if n, err := strconv.ParseUint(num, 10, 64); err == nil && multiplier == 1 {
+		return n, nil
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid size %q", s)
+	}
+
+	size := f * multiplier
+	if size >= math.MaxUint64 {
+		return 0, errors.Errorf("invalid size %q: overflows uint64", s)
+	}
+
+	return uint64(size), nil
+}
+
+// FormatBytes formats size using the largest IEC unit which divides it exactly.
+func FormatBytes(size uint64) string {
+	for i, unit := range binaryByteUnits {
+		div := uint64(1) << (10 * (len(binaryByteUnits) - i))
+
+		if size != 0 && size%div == 0 {
+			return strconv.FormatUint(size/div, 10) + unit
+		}
+	}
+
+	return strconv.FormatUint(size, 10)
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBytes(t *testing.T) {
+	for s, expected := range map[string]uint64{
+		"0":       0,
+		"512":     512,
+		"512B":    512,
+		"64kB":    64000,
+		"1.5GB":   1500000000,
+		"16MiB":   16 << 20,
+		"16 mi":   16 << 20,
+		"0.5KiB":  512,
+		"2TiB":    2 << 40,
+		" 10Gi  ": 10 << 30,
+	} {
+		size, err := ParseBytes(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, size, s)
+	}
+
+	for _, s := range []string{"", "MiB", "-1", "1XB", "1.2.3KB", "100000PiB"} {
+		_, err := ParseBytes(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "0", FormatBytes(0))
+	assert.Equal(t, "1000", FormatBytes(1000))
+	assert.Equal(t, "1KiB", FormatBytes(1024))
+	assert.Equal(t, "1536KiB", FormatBytes(1536<<10))
+	assert.Equal(t, "16MiB", FormatBytes(16<<20))
+	assert.Equal(t, "3GiB", FormatBytes(3<<30))
+}
@@ -0,0 +1,22 @@
+package cliv3
+
+import (
+	"github.com/urfave/cli/v3"
+)
+
+// genericValue wraps the generic flag value,
+// cli/v3 passes result of Get to the flag action as cli.Value,
+// so Get returns the wrapped value instead of the underlying one.
+type genericValue struct {
+	cli.Value
+}
+
+// Generic returns the value for cli.GenericFlag,
+// flag action and cmd.Generic receive the given value.
+func Generic(value cli.Value) cli.Value {
+	return &genericValue{Value: value}
+}
+
+func (v *genericValue) Get() any {
+	return v.Value
+}
@@ -0,0 +1,36 @@
+package cliv3
+
+import (
+	"context"
+	"testing"
+
+	config "github.com/partyzanex/cli-config-gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v3"
+)
+
+func TestGeneric(t *testing.T) {
+	size := config.NewValue("test").Set("test", uint64(1024))
+
+	cmd := &cli.Command{
+		Flags: []cli.Flag{
+			&cli.GenericFlag{
+				Name:  "size",
+				Value: Generic(config.NewBytesValue(size.Bytes())),
+				Action: func(_ context.Context, _ *cli.Command, v cli.Value) error {
+					size.SetGeneric("test", v)
+
+					return nil
+				},
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			assert.Equal(t, "2MiB", cmd.Generic("size").String())
+
+			return nil
+		},
+	}
+
+	assert.NoError(t, cmd.Run(context.Background(), []string{"app", "--size", "2MiB"}))
+	assert.Equal(t, uint64(2<<20), size.Bytes())
+}
@@ -0,0 +1,119 @@
+// Package cliv3 contains runtime helpers for config packages generated for urfave/cli/v3.
+package cliv3
+
+import (
+	"reflect"
+
+	config "github.com/partyzanex/cli-config-gen"
+	"github.com/urfave/cli/v3"
+)
+
+// NewConfigEntry returns the entry for the flag with given name, the value should be resolved by the caller,
+// the source is looked up in cmd by the name and then by old names of renamed flag.
+func NewConfigEntry(cmd *cli.Command, name string, value interface{}, secret bool, renamedFrom ...string) config.ConfigEntry {
+	entry := config.NewConfigEntry(nil, name, value, secret)
+	entry.Source, entry.EnvVar = lookupSource(cmd, name)
+
+	for _, old := range renamedFrom {
+		if entry.Source != config.SourceDefault {
+			break
+		}
+
+		entry.Source, entry.EnvVar = lookupSource(cmd, old)
+	}
+
+	return entry
+}
+
+func lookupSource(cmd *cli.Command, name string) (source, envVar string) {
+	if cmd == nil || !cmd.IsSet(name) {
+		return config.SourceDefault, ""
+	}
+
+	flag := lookupFlag(cmd, name)
+	if flag == nil {
+		return config.SourceArg, ""
+	}
+
+	sources, tracked := flagSources(flag)
+	if !tracked {
+		// args can't be told from sources which are not tracked,
+		// the set environment variable of the flag is reported.
+		var envVars []string
+		if df, ok := flag.(cli.DocGenerationFlag); ok {
+			envVars = df.GetEnvVars()
+		}
+
+		return config.SourceOf(false, envVars)
+	}
+
+	for _, s := range sources {
+		if !s.found {
+			continue
+		}
+
+		if s.IsFromEnv() {
+			return config.SourceEnv, s.Key()
+		}
+
+		return config.SourceFile, ""
+	}
+
+	return config.SourceOf(true, nil)
+}
+
+// flagSources returns sources of the flag created by Sources,
+// it reports false if the flag has other sources.
+// Sources is not a part of cli.Flag interface, so it's read by reflection.
+func flagSources(flag cli.Flag) ([]*trackedSource, bool) {
+	v := reflect.ValueOf(flag)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	field := v.Elem().FieldByName("Sources")
+	if !field.IsValid() || !field.CanInterface() {
+		return nil, false
+	}
+
+	chain, ok := field.Interface().(cli.ValueSourceChain)
+	if !ok {
+		return nil, false
+	}
+
+	sources := make([]*trackedSource, 0, len(chain.Chain))
+
+	for _, source := range chain.Chain {
+		tracked, ok := source.(*trackedSource)
+		if !ok {
+			return nil, false
+		}
+
+		sources = append(sources, tracked)
+	}
+
+	return sources, true
+}
+
+func lookupFlag(cmd *cli.Command, name string) cli.Flag {
+	for _, c := range cmd.Lineage() {
+		for _, flag := range c.Flags {
+			for _, n := range flag.Names() {
+				if n == name {
+					return flag
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetByArgs returns the func which reports whether the flag with given name was set by args parsed by cmd.
+func SetByArgs(cmd *cli.Command) func(name string) bool {
+	return func(name string) bool {
+		source, _ := lookupSource(cmd, name)
+
+		return source == config.SourceArg
+	}
+}
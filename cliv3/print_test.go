@@ -0,0 +1,59 @@
+package cliv3
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	config "github.com/partyzanex/cli-config-gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v3"
+)
+
+func TestNewConfigEntry(t *testing.T) {
+	err := os.Setenv("TEST_CLIV3_PORT", "8080")
+	assert.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "user")
+	assert.NoError(t, os.WriteFile(file, []byte("admin"), 0o600))
+
+	var entries []config.ConfigEntry
+
+	newCmd := func() *cli.Command {
+		return &cli.Command{
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "host", Value: "localhost"},
+				&cli.IntFlag{Name: "port", Sources: EnvVars("TEST_CLIV3_PORT")},
+				&cli.StringFlag{Name: "user", Sources: Sources(cli.EnvVar("TEST_CLIV3_USER"), cli.File(file))},
+				&cli.StringSliceFlag{Name: "tags", Value: config.NewValue("test").SetStringSlice("test", "a", "b").StringSliceValue()},
+			},
+			Action: func(_ context.Context, cmd *cli.Command) error {
+				entries = []config.ConfigEntry{
+					NewConfigEntry(cmd, "host", cmd.String("host"), false),
+					NewConfigEntry(cmd, "port", cmd.Int("port"), true),
+					NewConfigEntry(cmd, "user", cmd.String("user"), false),
+					NewConfigEntry(cmd, "tags", cmd.StringSlice("tags"), false),
+				}
+
+				return nil
+			},
+		}
+	}
+
+	err = newCmd().Run(context.Background(), []string{"app", "--tags", "c"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []config.ConfigEntry{
+		{Name: "host", Value: "localhost", Source: config.SourceDefault},
+		{Name: "port", Value: "******", Source: config.SourceEnv, EnvVar: "TEST_CLIV3_PORT"},
+		{Name: "user", Value: "admin", Source: config.SourceFile},
+		{Name: "tags", Value: []string{"c"}, Source: config.SourceArg},
+	}, entries)
+
+	// args passed to cmd.Run take precedence over sources
+	err = newCmd().Run(context.Background(), []string{"app", "--port", "9090"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, config.ConfigEntry{Name: "port", Value: "******", Source: config.SourceArg}, entries[1])
+}
@@ -0,0 +1,63 @@
+package cliv3
+
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+)
+
+// RequiredRenamed returns a hidden flag which checks that the required renamed flag is set by its name
+// or by one of old names, the flags are looked up in given flags after parsing. Old names are defined
+// as hidden flags and cli checks required flags only by their names, so the renamed flag itself
+// is not marked as required.
+func RequiredRenamed(flags []cli.Flag, name string, renamedFrom ...string) cli.Flag {
+	return &requiredRenamedFlag{flags: flags, name: name, names: append([]string{name}, renamedFrom...)}
+}
+
+type requiredRenamedFlag struct {
+	flags []cli.Flag
+	name  string
+	// names contains the flag name and old names.
+	names []string
+}
+
+func (f *requiredRenamedFlag) String() string {
+	return ""
+}
+
+func (f *requiredRenamedFlag) Get() any {
+	return nil
+}
+
+func (f *requiredRenamedFlag) PreParse() error {
+	return nil
+}
+
+// PostParse returns an error if none of the flag and its old names is set.
+func (f *requiredRenamedFlag) PostParse() error {
+	for _, flag := range f.flags {
+		if flag.IsSet() && slices.ContainsFunc(flag.Names(), f.hasName) {
+			return nil
+		}
+	}
+
+	return errors.Errorf("required flag %q not set", f.name)
+}
+
+func (f *requiredRenamedFlag) hasName(name string) bool {
+	return slices.Contains(f.names, name)
+}
+
+func (f *requiredRenamedFlag) Set(string, string) error {
+	return nil
+}
+
+// Names returns the flag name, the flag itself is looked up by cli after the renamed flag.
+func (f *requiredRenamedFlag) Names() []string {
+	return []string{f.name}
+}
+
+func (f *requiredRenamedFlag) IsSet() bool {
+	return false
+}
@@ -0,0 +1,41 @@
+package cliv3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v3"
+)
+
+func TestRequiredRenamed(t *testing.T) {
+	var oldSet bool
+
+	run := func(args ...string) error {
+		flags := []cli.Flag{
+			&cli.IntFlag{Name: "workers", Sources: EnvVars("TEST_CLIV3_WORKERS")},
+			&cli.IntFlag{Name: "threads", Hidden: true},
+		}
+
+		cmd := &cli.Command{
+			Flags: append(flags, RequiredRenamed(flags, "workers", "threads")),
+			Action: func(_ context.Context, cmd *cli.Command) error {
+				oldSet = SetByArgs(cmd)("threads")
+
+				return nil
+			},
+		}
+
+		return cmd.Run(context.Background(), append([]string{"app"}, args...))
+	}
+
+	assert.NoError(t, run("--workers", "2"))
+	assert.False(t, oldSet)
+	assert.NoError(t, run("--threads", "2"))
+	assert.True(t, oldSet)
+	assert.EqualError(t, run(), `required flag "workers" not set`)
+
+	t.Setenv("TEST_CLIV3_WORKERS", "4")
+	assert.NoError(t, run())
+	assert.False(t, oldSet)
+}
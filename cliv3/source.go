@@ -0,0 +1,52 @@
+package cliv3
+
+import (
+	"github.com/urfave/cli/v3"
+)
+
+// trackedSource records whether the wrapped source provided the flag value,
+// cli/v3 looks up sources of the flag only if it was not set by args.
+type trackedSource struct {
+	cli.ValueSource
+	found bool
+}
+
+// Sources returns the chain of given sources which records the source of the flag value,
+// it's reported by NewConfigEntry.
+func Sources(sources ...cli.ValueSource) cli.ValueSourceChain {
+	chain := cli.ValueSourceChain{Chain: make([]cli.ValueSource, 0, len(sources))}
+
+	for _, source := range sources {
+		chain.Chain = append(chain.Chain, &trackedSource{ValueSource: source})
+	}
+
+	return chain
+}
+
+// EnvVars returns the chain of environment variables which records the source of the flag value,
+// it's reported by NewConfigEntry.
+func EnvVars(keys ...string) cli.ValueSourceChain {
+	return Sources(cli.EnvVars(keys...).Chain...)
+}
+
+func (s *trackedSource) Lookup() (string, bool) {
+	value, found := s.ValueSource.Lookup()
+	s.found = found
+
+	return value, found
+}
+
+// IsFromEnv keeps environment variables of wrapped sources in the help.
+func (s *trackedSource) IsFromEnv() bool {
+	env, ok := s.ValueSource.(cli.EnvValueSource)
+
+	return ok && env.IsFromEnv()
+}
+
+func (s *trackedSource) Key() string {
+	if env, ok := s.ValueSource.(cli.EnvValueSource); ok {
+		return env.Key()
+	}
+
+	return ""
+}
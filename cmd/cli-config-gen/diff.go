@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/partyzanex/cli-config-gen/codegen"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+const exitCodeFlag = "exit-code"
+
+func diffCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "Compare two versions of config.yaml and report changes of flags and environments",
+		ArgsUsage: "OLD [NEW]",
+		Description: "OLD and NEW are config.yaml files or git revisions as REV:PATH, e.g. main:config.yaml.\n" +
+			"OLD without a colon which is not a file is a git revision of --source, NEW is --source by default.\n" +
+			"The command fails if breaking changes are found.",
+		Flags: []cli.Flag{
+			&cli.PathFlag{
+				Name:    sourceFileFlag,
+				Aliases: []string{"s", "src"},
+				Usage:   "Path to source config.yaml file",
+				Value:   "./config.yaml",
+			},
+			&cli.StringFlag{
+				Name:    formatFlag,
+				Aliases: []string{"f"},
+				Usage:   "Report format (text, json)",
+				Value:   codegen.DiffFormatText,
+			},
+			&cli.BoolFlag{
+				Name:  exitCodeFlag,
+				Usage: "Fail if any changes are found, not only breaking changes",
+			},
+		},
+		Action: diffAction,
+	}
+}
+
+func diffAction(ctx *cli.Context) error {
+	if ctx.NArg() < 1 || ctx.NArg() > 2 {
+		return errors.New("expected OLD and optional NEW arguments")
+	}
+
+	source := ctx.Path(sourceFileFlag)
+
+	diff := &codegen.Diff{
+		Old:      ctx.Args().Get(0),
+		New:      source,
+		Format:   ctx.String(formatFlag),
+		ExitCode: ctx.Bool(exitCodeFlag),
+	}
+
+	if ctx.NArg() == 2 {
+		diff.New = ctx.Args().Get(1)
+	}
+
+	if _, err := os.Stat(diff.Old); os.IsNotExist(err) && !strings.Contains(diff.Old, ":") {
+		diff.Old += ":" + source
+	}
+
+	return diff.Run(os.Stdout)
+}
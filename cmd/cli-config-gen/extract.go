@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/partyzanex/cli-config-gen/codegen"
+	"github.com/urfave/cli/v2"
+)
+
+func extractCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "extract",
+		Usage: "Write config.yaml reconstructed from config.go generated for cli/v2",
+		Flags: []cli.Flag{
+			&cli.PathFlag{
+				Name:    targetPathFlag,
+				Aliases: []string{"t"},
+				Usage:   "Path to generated config.go file",
+				Value:   "./internal/config/config.go",
+			},
+			&cli.PathFlag{
+				Name:    sourceFileFlag,
+				Aliases: []string{"s", "src"},
+				Usage:   "Path to written config.yaml file",
+				Value:   "./config.yaml",
+			},
+			&cli.BoolFlag{
+				Name:  forceFlag,
+				Usage: "Overwrite existing config.yaml",
+			},
+		},
+		Action: extractAction,
+	}
+}
+
+func extractAction(ctx *cli.Context) error {
+	extract := &codegen.Extract{
+		GoFile:     ctx.Path(targetPathFlag),
+		SourceFile: ctx.Path(sourceFileFlag),
+		Force:      ctx.Bool(forceFlag),
+	}
+
+	return extract.Run()
+}
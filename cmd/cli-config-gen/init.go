@@ -0,0 +1,84 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/partyzanex/cli-config-gen/codegen"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	nameFlag  = "name"
+	descFlag  = "desc"
+	envFlag   = "env"
+	fromFlag  = "from"
+	forceFlag = "force"
+)
+
+func initCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "init",
+		Usage: "Write starter config.yaml with an example of each flag type or convert flags of Go package",
+		Flags: []cli.Flag{
+			&cli.PathFlag{
+				Name:    sourceFileFlag,
+				Aliases: []string{"s", "src"},
+				Usage:   "Path to written config.yaml file",
+				Value:   "./config.yaml",
+			},
+			&cli.StringFlag{
+				Name:  nameFlag,
+				Usage: "App name, name of the current directory by default",
+			},
+			&cli.StringFlag{
+				Name:  descFlag,
+				Usage: "App description",
+			},
+			&cli.StringSliceFlag{
+				Name:  envFlag,
+				Usage: "Environment names, the first one is default",
+				Value: cli.NewStringSlice("local", "prod"),
+			},
+			&cli.StringFlag{
+				Name:  fromFlag,
+				Usage: "Go package with urfave/cli flags slice or struct with `flag` tags, e.g. ./cmd/app",
+			},
+			&cli.BoolFlag{
+				Name:  forceFlag,
+				Usage: "Overwrite existing config.yaml",
+			},
+		},
+		Action: initAction,
+	}
+}
+
+func initAction(ctx *cli.Context) error {
+	name := ctx.String(nameFlag)
+	if name == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		name = filepath.Base(wd)
+	}
+
+	initConfig := &codegen.Init{
+		SourceFile: ctx.Path(sourceFileFlag),
+		Name:       name,
+		Desc:       ctx.String(descFlag),
+		Env:        ctx.StringSlice(envFlag),
+		From:       ctx.String(fromFlag),
+		Force:      ctx.Bool(forceFlag),
+	}
+
+	err := initConfig.Run()
+
+	for _, warning := range initConfig.Warnings {
+		log.Println("WARNING:", warning)
+	}
+
+	return err
+}
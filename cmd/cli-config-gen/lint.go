@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/partyzanex/cli-config-gen/codegen"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	formatFlag = "format"
+	ruleFlag   = "rule"
+)
+
+func lintCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "lint",
+		Usage: "Check config.yaml for clashing names and other issues accepted by the generator",
+		Flags: []cli.Flag{
+			&cli.PathFlag{
+				Name:    sourceFileFlag,
+				Aliases: []string{"s", "src"},
+				Usage:   "Path to source config.yaml file",
+				Value:   "./config.yaml",
+			},
+			&cli.StringFlag{
+				Name:    formatFlag,
+				Aliases: []string{"f"},
+				Usage:   "Report format (text, json, sarif)",
+				Value:   codegen.LintFormatText,
+			},
+			&cli.StringSliceFlag{
+				Name:  ruleFlag,
+				Usage: "Severity of rule as NAME=SEVERITY (off, warning, error), overrides lint.rules of config.yaml",
+			},
+		},
+		Action: lintAction,
+	}
+}
+
+func lintAction(ctx *cli.Context) error {
+	lint := &codegen.Lint{
+		SourceFile: ctx.Path(sourceFileFlag),
+		Format:     ctx.String(formatFlag),
+		Rules:      make(map[string]codegen.Severity),
+	}
+
+	for _, s := range ctx.StringSlice(ruleFlag) {
+		name, severity, ok := strings.Cut(s, "=")
+		if !ok {
+			return errors.Errorf("invalid rule %q, expected NAME=SEVERITY", s)
+		}
+
+		lint.Rules[name] = codegen.Severity(severity)
+	}
+
+	return lint.Run(os.Stdout)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+
+	"github.com/partyzanex/cli-config-gen/codegen"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	lockFileFlag = "lock"
+	updateFlag   = "update"
+)
+
+func lockCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "lock",
+		Usage: "Check that public names of flags locked in config.lock.yaml are not removed from config.yaml",
+		Flags: []cli.Flag{
+			&cli.PathFlag{
+				Name:    sourceFileFlag,
+				Aliases: []string{"s", "src"},
+				Usage:   "Path to source config.yaml file",
+				Value:   "./config.yaml",
+			},
+			&cli.PathFlag{
+				Name:  lockFileFlag,
+				Usage: "Path to lock file, " + codegen.DefaultLockFile + " next to config.yaml by default",
+			},
+			&cli.BoolFlag{
+				Name:  updateFlag,
+				Usage: "Write public names of flags of config.yaml to the lock file",
+			},
+		},
+		Action: lockAction,
+	}
+}
+
+func lockAction(ctx *cli.Context) error {
+	lock := &codegen.Lock{
+		SourceFile: ctx.Path(sourceFileFlag),
+		LockFile:   ctx.Path(lockFileFlag),
+		Update:     ctx.Bool(updateFlag),
+	}
+
+	return lock.Run(os.Stdout)
+}
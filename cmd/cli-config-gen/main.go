@@ -4,7 +4,7 @@ import (
 	"log"
 	"os"
 
-	config "github.com/partyzanex/cli-config-gen"
+	"github.com/partyzanex/cli-config-gen/codegen"
 	"github.com/urfave/cli/v2"
 )
 
@@ -13,12 +13,16 @@ const (
 	targetPathFlag   = "target"
 	packageFlag      = "package"
 	templatePathFlag = "template"
+	targetLibFlag    = "target-lib"
+	outputFlag       = "output"
+	testsFlag        = "tests"
 )
 
 func main() {
 	app := new(cli.App)
 	app.Usage = "cli tool for generates config package from YAML"
 	app.Action = action
+	app.Commands = []*cli.Command{initCommand(), extractCommand(), lintCommand(), diffCommand(), lockCommand()}
 	app.Flags = []cli.Flag{
 		&cli.PathFlag{
 			Name:       sourceFileFlag,
@@ -50,6 +54,21 @@ func main() {
 			Usage:   "Path to template file",
 			Value:   "",
 		},
+		&cli.StringFlag{
+			Name:    targetLibFlag,
+			Aliases: []string{"lib"},
+			Usage:   "Target cli library of built-in template (cli/v2, cli/v3, cobra, flag)",
+			Value:   codegen.TargetLibCLIv2,
+		},
+		&cli.StringSliceFlag{
+			Name:    outputFlag,
+			Aliases: []string{"o"},
+			Usage:   "Generated file as PATH[=TEMPLATE], overrides outputs of source config, target and template",
+		},
+		&cli.BoolFlag{
+			Name:  testsFlag,
+			Usage: "Generate config_gen_test.go next to the first generated file",
+		},
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -58,11 +77,22 @@ func main() {
 }
 
 func action(ctx *cli.Context) error {
-	gen := &config.Codegen{
+	gen := &codegen.Codegen{
 		TemplatePath: ctx.Path(templatePathFlag),
 		SourceFile:   ctx.Path(sourceFileFlag),
 		TargetPath:   ctx.Path(targetPathFlag),
 		PackageName:  ctx.String(packageFlag),
+		TargetLib:    ctx.String(targetLibFlag),
+		Tests:        ctx.Bool(testsFlag),
+	}
+
+	for _, s := range ctx.StringSlice(outputFlag) {
+		output, err := codegen.ParseOutput(s)
+		if err != nil {
+			return err
+		}
+
+		gen.Outputs = append(gen.Outputs, output)
 	}
 
 	return gen.Run()
@@ -0,0 +1,241 @@
+package codegen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateData is the data passed to config templates.
+type TemplateData struct {
+	// Source is the decoded source config: App and sorted Flags.
+	*Source
+
+	// PackageName is the name of generated package.
+	PackageName string
+	// SourceFile is the path of source config file.
+	SourceFile string
+	// TargetLib is the target library, it selects the built-in template.
+	TargetLib string
+	// Version is the version of the generator.
+	Version string
+	// OutputPath is the path of the generated file.
+	OutputPath string
+}
+
+// Target libraries of generated code.
+const (
+	TargetLibCLIv2 = "cli/v2"
+	TargetLibCLIv3 = "cli/v3"
+	TargetLibCobra = "cobra"
+	TargetLibFlag  = "flag"
+)
+
+var targetLibTemplates = map[string]string{
+	TargetLibCLIv2: "config.tpl",
+	TargetLibCLIv3: "config_cli_v3.tpl",
+	TargetLibCobra: "config_cobra.tpl",
+	TargetLibFlag:  "config_flag.tpl",
+}
+
+type Codegen struct {
+	source *Source
+
+	TemplatePath string
+	PackageName  string
+	SourceFile   string
+	TargetPath   string
+	// TargetLib selects the built-in template, cli/v2 is used by default.
+	TargetLib string
+	// Funcs contains extra template functions, they override built-in functions of TemplateFuncs.
+	Funcs template.FuncMap
+	// Outputs overrides outputs of the source config, TemplatePath and TargetPath are used
+	// if both of them are empty.
+	Outputs []Output
+	// Tests enables generation of config_gen_test.go next to the first output, it checks defaults
+	// of each environment and precedence of args and environment variables.
+	Tests bool
+}
+
+const (
+	testTemplate = "config_gen_test.tpl"
+	testFile     = "config_gen_test.go"
+)
+
+func (g *Codegen) Run() error {
+	err := g.readSource()
+	if err != nil {
+		return err
+	}
+
+	outputs, err := g.outputs()
+	if err != nil {
+		return err
+	}
+
+	files := make([]*generatedFile, 0, len(outputs)+1)
+
+	for _, output := range outputs {
+		tpl, err := g.readTemplate(output.Template)
+		if err != nil {
+			return err
+		}
+
+		file, err := g.execute(tpl, output.Path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, file)
+	}
+
+	if g.Tests {
+		file, err := g.generateTests(filepath.Join(filepath.Dir(outputs[0].Path), testFile))
+		if err != nil {
+			return err
+		}
+
+		files = append(files, file)
+	}
+
+	err = writeFiles(files)
+	if err != nil {
+		return err
+	}
+
+	if len(g.Outputs) == 0 && len(g.source.Outputs) == 0 {
+		return nil
+	}
+
+	return g.removeStaleFiles(files)
+}
+
+func (g *Codegen) execute(tpl *template.Template, path string) (*generatedFile, error) {
+	var buf bytes.Buffer
+
+	err := tpl.Execute(&buf, &TemplateData{
+		Source:      g.source,
+		PackageName: g.PackageName,
+		SourceFile:  g.SourceFile,
+		TargetLib:   g.targetLib(),
+		OutputPath:  path,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot execute template of %s", path)
+	}
+
+	return &generatedFile{path: path, content: buf.Bytes()}, nil
+}
+
+// generateTests renders the built-in test template, it's parsed with the built-in template of the target lib,
+// so both of them share functions and sub-templates.
+func (g *Codegen) generateTests(path string) (*generatedFile, error) {
+	tpl, err := g.readTemplate("")
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := TemplateFS.ReadFile(testTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read template")
+	}
+
+	tpl, err = tpl.New(testTemplate).Parse(string(b))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse test template")
+	}
+
+	return g.execute(tpl, path)
+}
+
+// readTemplate parses the built-in template of the target lib, it's named by its file name, e.g. config.tpl.
+// The custom template is parsed after it, so it may execute the built-in template
+// and redefine its sub-templates, e.g. "extra".
+func (g *Codegen) readTemplate(path string) (*template.Template, error) {
+	funcs := TemplateFuncs(g.source)
+	for name, fn := range g.Funcs {
+		funcs[name] = fn
+	}
+
+	name, ok := targetLibTemplates[g.targetLib()]
+	if !ok {
+		return nil, errors.Errorf("unsupported target lib %q", g.TargetLib)
+	}
+
+	builtin, err := TemplateFS.ReadFile(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read template")
+	}
+
+	tpl, err := template.New(name).Funcs(funcs).Parse(string(builtin))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse template")
+	}
+
+	if path == "" {
+		return tpl, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read template file %s", path)
+	}
+
+	tpl, err = tpl.New(filepath.Base(path)).Parse(string(b))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse template file %s", path)
+	}
+
+	return tpl, nil
+}
+
+func (g *Codegen) targetLib() string {
+	if g.TargetLib == "" {
+		return TargetLibCLIv2
+	}
+
+	return g.TargetLib
+}
+
+func (g *Codegen) readSource() error {
+	src, err := os.Open(g.SourceFile)
+	if err != nil {
+		return errors.Wrap(err, "cannot open source config file")
+	}
+
+	source := new(Source)
+
+	err = yaml.NewDecoder(src).Decode(source)
+	if err != nil {
+		return errors.Wrap(err, "cannot decode source file")
+	}
+
+	g.source = source
+
+	return g.checkSource()
+}
+
+// checkSource checks that identifiers of generated code don't clash with identifiers of the runtime package
+// which is imported with dot by templates of all target libs except flag, aliases of cobra flags are checked
+// as they are resolved by the single map.
+func (g *Codegen) checkSource() error {
+	if g.targetLib() == TargetLibCobra {
+		if err := g.source.Flags.checkAliases(); err != nil {
+			return errors.Wrapf(err, "cannot generate code of %s target lib", g.targetLib())
+		}
+	}
+
+	if g.targetLib() == TargetLibFlag {
+		return nil
+	}
+
+	if err := g.source.checkSymbols(true); err != nil {
+		return errors.Wrapf(err, "cannot generate code of %s target lib", g.targetLib())
+	}
+
+	return nil
+}
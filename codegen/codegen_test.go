@@ -0,0 +1,199 @@
+package codegen
+
+import (
+	"flag"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// TestCodegen_Run_golden generates code of testdata/golden/*.yaml and ../config.example.yaml for each target lib,
+// compares it with golden files and type checks generated packages with generated tests.
+func TestCodegen_Run_golden(t *testing.T) {
+	sources, err := filepath.Glob(filepath.Join("testdata", "golden", "*.yaml"))
+	assert.NoError(t, err)
+
+	sources = append(sources, filepath.Join("..", "config.example.yaml"))
+	imp := importer.ForCompiler(token.NewFileSet(), "source", nil)
+
+	for _, source := range sources {
+		name := strings.TrimSuffix(filepath.Base(source), ".yaml")
+
+		for _, lib := range targetLibs() {
+			t.Run(name+"/"+lib, func(t *testing.T) {
+				dir := t.TempDir()
+				gen := &Codegen{
+					SourceFile:  source,
+					TargetPath:  filepath.Join(dir, "config.go"),
+					PackageName: "config",
+					TargetLib:   lib,
+					Tests:       true,
+				}
+
+				if !assert.NoError(t, gen.Run()) {
+					return
+				}
+
+				code := formatFile(t, gen.TargetPath)
+				assertGolden(t, filepath.Join("testdata", "golden", name, strings.ReplaceAll(lib, "/", "_")+".go.golden"), code)
+
+				typeCheck(t, imp, filepath.Join("testdata", "golden"), map[string][]byte{
+					"config.go": code,
+					testFile:    formatFile(t, filepath.Join(dir, testFile)),
+				})
+			})
+		}
+	}
+}
+
+// TestCodegen_Run_errors checks errors of invalid sources in testdata/errors,
+// they must be reported by Run instead of panics.
+func TestCodegen_Run_errors(t *testing.T) {
+	sources, err := filepath.Glob(filepath.Join("testdata", "errors", "*.yaml"))
+	assert.NoError(t, err)
+
+	for _, source := range sources {
+		name := strings.TrimSuffix(filepath.Base(source), ".yaml")
+
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			gen := &Codegen{
+				SourceFile:  source,
+				TargetPath:  filepath.Join(dir, "config.go"),
+				PackageName: "config",
+			}
+
+			var err error
+
+			assert.NotPanics(t, func() {
+				err = gen.Run()
+			})
+
+			if assert.Error(t, err) {
+				msg := strings.ReplaceAll(err.Error(), dir+string(filepath.Separator), "")
+				assertGolden(t, strings.TrimSuffix(source, ".yaml")+".golden", []byte(msg+"\n"))
+			}
+		})
+	}
+}
+
+func TestCodegen_Run_cobraAliases(t *testing.T) {
+	for flags, msg := range map[string]string{
+		"{ host: { type: string, aliases: [ addr ] }, listen: { type: string, aliases: [ addr ] } }": `alias "addr" of flag "listen" is already declared by flag "host"`,
+		"{ host: { type: string, aliases: [ listen ] }, listen: { type: string } }":                  `alias "listen" of flag "host" is the name of a flag`,
+	} {
+		dir := t.TempDir()
+		source := filepath.Join(dir, "config.yaml")
+
+		assert.NoError(t, os.WriteFile(source, []byte("app: { name: app, env: [ local ] }\nflags: "+flags+"\n"), 0o644))
+
+		gen := &Codegen{
+			SourceFile:  source,
+			TargetPath:  filepath.Join(dir, "config.go"),
+			PackageName: "config",
+			TargetLib:   TargetLibCobra,
+		}
+
+		assert.EqualError(t, gen.Run(), "cannot generate code of cobra target lib: "+msg)
+	}
+}
+
+func targetLibs() []string {
+	libs := make([]string, 0, len(targetLibTemplates))
+	for lib := range targetLibTemplates {
+		libs = append(libs, lib)
+	}
+
+	sort.Strings(libs)
+
+	return libs
+}
+
+func formatFile(t *testing.T, path string) []byte {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	code, err := format.Source(b)
+	assert.NoError(t, err, "generated code of %s is not valid Go", filepath.Base(path))
+
+	return code
+}
+
+// assertGolden compares got with the golden file, the file is rewritten if -update is set.
+func assertGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+
+	if *update {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		assert.NoError(t, os.WriteFile(path, got, 0o644))
+
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if !assert.NoError(t, err, "run go test -update to create golden files") {
+		return
+	}
+
+	if line, ok := diffLine(string(want), string(got)); !ok {
+		t.Errorf("golden file %s is outdated at line %d, run go test -update", path, line)
+	}
+}
+
+// diffLine returns the number of the first line which differs.
+func diffLine(want, got string) (int, bool) {
+	wantLines, gotLines := strings.Split(want, "\n"), strings.Split(got, "\n")
+
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		if i >= len(wantLines) || i >= len(gotLines) || wantLines[i] != gotLines[i] {
+			return i + 1, false
+		}
+	}
+
+	return 0, true
+}
+
+// typeCheck type checks files as a single package, imports are resolved from the module of dir.
+func typeCheck(t *testing.T, imp types.Importer, dir string, files map[string][]byte) {
+	t.Helper()
+
+	dir, err := filepath.Abs(dir)
+	assert.NoError(t, err)
+
+	var (
+		fset   = token.NewFileSet()
+		parsed = make([]*ast.File, 0, len(files))
+	)
+
+	for name, code := range files {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), code, 0)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		parsed = append(parsed, file)
+	}
+
+	conf := types.Config{
+		Importer: imp,
+		Error: func(err error) {
+			t.Error(err)
+		},
+	}
+
+	_, _ = conf.Check("config", fset, parsed, nil)
+}
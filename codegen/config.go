@@ -0,0 +1,6 @@
+package codegen
+
+import "embed"
+
+//go:embed config.tpl config_cli_v3.tpl config_cobra.tpl config_flag.tpl config_gen_test.tpl config_init.tpl
+var TemplateFS embed.FS
@@ -0,0 +1,160 @@
+# Source of generated config package, run cli-config-gen -s {{.SourceFile}} to generate it.
+# Each flag is read from args, environment variables and the default value of the current environment.
+app:
+  name: {{quote .Name}} # prefix of environment variables: {{.EnvPrefix}}
+  desc: {{quote .Desc}}
+  # the first environment is used by default, it's selected by {{.EnvPrefix}}ENV or --env
+  env:{{range .Env}}
+    - {{.}}{{end}}
+
+flags:
+  # flag name is the key, use `flag` to override it; `desc` is shown in help,
+  # `env` adds environment variables and `value` is the default of all environments or per environment.
+  name:
+    type: string
+    desc: Service name
+    aliases: [ n ]
+    value: {{quote .Name}}
+  password:
+    type: string
+    desc: Redacted by --print-config
+    secret: true
+  config-file:
+    type: string
+    desc: Path to config file
+    takesFile: true
+  log-format:
+    type: enum
+    desc: Log format
+    enum: [ text, json ]
+    value: text
+  debug:
+    type: bool
+    desc: Enable debug logging
+    value:{{range $i, $env := .Env}}
+      {{$env}}: {{if eq $i 0}}true{{else}}false{{end}}{{end}}
+  port:
+    type: int
+    desc: HTTP port
+    required: true
+    env: [ http-port ]
+  workers:
+    type: uint
+    desc: Number of workers
+    value: 4
+  shard:
+    type: int32
+    desc: Shard number
+    value: 0
+  queue-size:
+    type: uint32
+    desc: Size of the queue
+    value: 1000
+  offset:
+    type: int64
+    desc: Start offset, -1 is the end
+    value: -1
+  max-items:
+    type: uint64
+    desc: Max number of items
+    value: 100000
+  ratio:
+    type: float32
+    desc: Ratio of sampled requests
+    value: 0.5
+  sample-rate:
+    type: float64
+    desc: Sample rate of traces
+    value: 0.01
+  timeout:
+    type: duration
+    desc: Request timeout
+    value: 30s
+  start-at:
+    type: timestamp
+    desc: Date of the first processed record
+    layout: DateOnly # Go layout or name of time constant, RFC3339 by default
+    timezone: UTC
+    value: 2024-01-01
+  max-body-size:
+    type: bytes
+    desc: Max size of request body
+    value: 16MiB
+  upstream:
+    type: url
+    desc: Upstream API URL
+    value: https://example.com/api
+  listen:
+    type: hostPort
+    desc: Listen address
+    value: ":8080"
+  bind-ip:
+    type: ip
+    desc: IP of outgoing connections
+    value: 127.0.0.1
+  tags:
+    type: stringSlice
+    desc: Tags of the service
+    value: [ a, b ]
+  ids:
+    type: intSlice
+    desc: IDs of processed items
+    value: [ 1, 2, 3 ]
+  ports:
+    type: uintSlice
+    desc: Ports of health checks
+    value: [ 80, 443 ]
+  offsets:
+    type: int64Slice
+    desc: Offsets of partitions
+    value: [ -1, 0, 1 ]
+  sizes:
+    type: uint64Slice
+    desc: Buffer sizes
+    value: [ 1024, 4096 ]
+  weights:
+    type: float64Slice
+    desc: Weights of upstreams
+    value: [ 0.5, 1.5 ]
+  toggles:
+    type: boolSlice
+    desc: Feature toggles
+    value: [ true, false ]
+  retry-backoff:
+    type: durationSlice
+    desc: Delays between retries
+    value: [ 100ms, 1s ]
+  features:
+    type: enumSlice
+    desc: Enabled features
+    enum: [ search, export ]
+    value: [ search ]
+  trusted-proxies:
+    type: ipSlice
+    desc: IPs of trusted proxies
+    value: [ 10.0.0.1, "::1" ]
+  allowlist:
+    type: cidrSlice
+    desc: Allowed networks
+    value: [ 10.0.0.0/8 ]
+  header:
+    type: stringMap
+    desc: Headers of upstream requests
+    value: { X-Source: {{quote .Name}} }
+  limits:
+    type: intMap
+    desc: Rate limits by clients
+    value: { default: 10 }
+  quotas:
+    type: int64Map
+    desc: Quotas by clients
+    value: { default: 1000 }
+  thresholds:
+    type: float64Map
+    desc: Alert thresholds by metrics
+    value: { default: 0.9 }
+  log-level:
+    type: custom
+    desc: Log level
+    goType: log/slog.Level # any type which implements encoding.TextUnmarshaler by pointer
+    value: info
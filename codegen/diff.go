@@ -0,0 +1,606 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Diff formats.
+const (
+	DiffFormatText = "text"
+	DiffFormatJSON = "json"
+)
+
+// Diff compares two versions of the source config and reports changes of flags and environments
+// which are visible to users of the app.
+type Diff struct {
+	// Old and New are paths of source config files or git revisions of files as REV:PATH,
+	// e.g. main:config.yaml, PATH is relative to the current directory.
+	Old, New string
+	// Format is the format of the report: text or json, text by default.
+	Format string
+	// ExitCode makes Run return an error if any changes are found,
+	// otherwise it returns an error only for breaking changes.
+	ExitCode bool
+}
+
+// ChangeKind is the kind of the change.
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change is the change of the app or of the flag.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// Flag is the name of the flag, it's empty for changes of the app.
+	Flag string `json:"flag,omitempty"`
+	// Env is the environment of the changed default value.
+	Env     string `json:"env,omitempty"`
+	Message string `json:"message"`
+	// Breaking marks changes which break existing command lines, environment variables or default values.
+	Breaking bool `json:"breaking"`
+}
+
+var changeSigns = map[ChangeKind]string{
+	ChangeAdded:   "+",
+	ChangeRemoved: "-",
+	ChangeChanged: "~",
+}
+
+func (change *Change) String() string {
+	s := changeSigns[change.Kind] + " " + change.Message
+	if change.Breaking {
+		s += " [breaking]"
+	}
+
+	return s
+}
+
+// Run compares sources and writes the report to w, it returns an error if breaking changes are found
+// or, if ExitCode is set, if any changes are found.
+func (d *Diff) Run(w io.Writer) error {
+	changes, err := d.Changes()
+	if err != nil {
+		return err
+	}
+
+	err = writeDiffReport(w, d.Format, changes)
+	if err != nil {
+		return err
+	}
+
+	var breaking int
+
+	for _, change := range changes {
+		if change.Breaking {
+			breaking++
+		}
+	}
+
+	switch {
+	case breaking > 0:
+		return errors.Errorf("%d breaking changes found", breaking)
+	case d.ExitCode && len(changes) > 0:
+		return errors.Errorf("%d changes found", len(changes))
+	default:
+		return nil
+	}
+}
+
+// Changes compares sources and returns changes of the app followed by changes of flags ordered by flag names.
+func (d *Diff) Changes() ([]*Change, error) {
+	oldSource, err := readSourceVersion(d.Old)
+	if err != nil {
+		return nil, err
+	}
+
+	newSource, err := readSourceVersion(d.New)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &sourceDiff{old: oldSource, new: newSource}
+	diff.app()
+	diff.flags()
+
+	if diff.err != nil {
+		return nil, errors.Wrapf(diff.err, "cannot compare %s and %s", d.Old, d.New)
+	}
+
+	return diff.changes, nil
+}
+
+// readSourceVersion reads and decodes the source config file or its git revision REV:PATH.
+func readSourceVersion(version string) (*Source, error) {
+	content, err := os.ReadFile(version)
+	if os.IsNotExist(err) && strings.Contains(version, ":") {
+		content, err = gitShow(version)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read source config %s", version)
+	}
+
+	source := new(Source)
+
+	err = yaml.Unmarshal(content, source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot decode source config %s", version)
+	}
+
+	return source, nil
+}
+
+// gitShow returns the file of the git revision REV:PATH, relative PATH is resolved against the current directory,
+// absolute PATH is resolved against the top-level directory of the repository.
+func gitShow(version string) ([]byte, error) {
+	rev, path, _ := strings.Cut(version, ":")
+
+	switch {
+	case filepath.IsAbs(path):
+		root, err := git("rev-parse", "--show-toplevel")
+		if err != nil {
+			return nil, err
+		}
+
+		// the top-level directory has symlinks resolved
+		if dir, err := filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+			path = filepath.Join(dir, filepath.Base(path))
+		}
+
+		rel, err := filepath.Rel(strings.TrimSpace(string(root)), path)
+		if err != nil || !filepath.IsLocal(rel) {
+			return nil, errors.Errorf("%s is outside of git repository %s", path, strings.TrimSpace(string(root)))
+		}
+
+		path = rel
+	case !strings.HasPrefix(path, "./") && !strings.HasPrefix(path, "../"):
+		path = "./" + path
+	}
+
+	return git("show", "--end-of-options", rev+":"+filepath.ToSlash(path))
+}
+
+// git runs the git command in the current directory and returns its output.
+func git(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Errorf("git %s: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
+
+// sourceDiff collects changes of sources.
+type sourceDiff struct {
+	old, new *Source
+	changes  []*Change
+	// err is the first error of invalid default values.
+	err error
+}
+
+func (d *sourceDiff) report(change *Change) {
+	d.changes = append(d.changes, change)
+}
+
+func (d *sourceDiff) app() {
+	if oldKey, newKey := d.old.App.EnvKey(), d.new.App.EnvKey(); oldKey != newKey {
+		d.report(&Change{
+			Kind:     ChangeChanged,
+			Message:  fmt.Sprintf("environment variable of --env changed from %s to %s", oldKey, newKey),
+			Breaking: true,
+		})
+	}
+
+	newEnvs := make(map[string]*Environment, len(d.new.App.Env))
+	for _, env := range d.new.App.Env {
+		newEnvs[env.String()] = env
+	}
+
+	oldEnvs := make(map[string]bool, len(d.old.App.Env))
+
+	for _, env := range d.old.App.Env {
+		oldEnvs[env.String()] = true
+
+		newEnv, ok := newEnvs[env.String()]
+		if !ok {
+			d.report(&Change{
+				Kind:     ChangeRemoved,
+				Message:  fmt.Sprintf("environment %q removed", env),
+				Breaking: true,
+			})
+
+			continue
+		}
+
+		for _, alias := range missing(env.Aliases, newEnv.Aliases) {
+			d.report(&Change{
+				Kind:     ChangeRemoved,
+				Message:  fmt.Sprintf("alias %q of environment %q removed", alias, env),
+				Breaking: true,
+			})
+		}
+
+		for _, alias := range missing(newEnv.Aliases, env.Aliases) {
+			d.report(&Change{
+				Kind:    ChangeAdded,
+				Message: fmt.Sprintf("alias %q of environment %q added", alias, env),
+			})
+		}
+	}
+
+	for _, env := range d.new.App.Env {
+		if !oldEnvs[env.String()] {
+			d.report(&Change{Kind: ChangeAdded, Message: fmt.Sprintf("environment %q added", env)})
+		}
+	}
+
+	if oldDefault, newDefault := defaultEnv(d.old), defaultEnv(d.new); oldDefault != newDefault {
+		d.report(&Change{
+			Kind:     ChangeChanged,
+			Message:  fmt.Sprintf("default environment changed from %q to %q", oldDefault, newDefault),
+			Breaking: true,
+		})
+	}
+}
+
+// defaultEnv returns the first environment which is used if the environment variable isn't set.
+func defaultEnv(source *Source) string {
+	if len(source.App.Env) == 0 {
+		return ""
+	}
+
+	return source.App.Env[0].String()
+}
+
+func (d *sourceDiff) flags() {
+	oldFlags := make(map[string]*Flag, len(d.old.Flags))
+	for _, flag := range d.old.Flags {
+		oldFlags[flag.Name] = flag
+	}
+
+	newFlags := make(map[string]*Flag, len(d.new.Flags))
+	renamed := make(map[string]*Flag)
+
+	for _, flag := range d.new.Flags {
+		newFlags[flag.Name] = flag
+
+		for _, name := range flag.RenamedFrom {
+			renamed[name] = flag
+		}
+	}
+
+	names := make([]string, 0, len(oldFlags)+len(newFlags))
+
+	for name := range oldFlags {
+		names = append(names, name)
+	}
+
+	for name := range newFlags {
+		if _, ok := oldFlags[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		oldFlag, newFlag := oldFlags[name], newFlags[name]
+
+		switch {
+		case oldFlag == nil:
+			d.added(newFlag, oldFlags)
+		case newFlag != nil:
+			d.flag(oldFlag, newFlag)
+		case renamed[name] != nil:
+			d.report(&Change{
+				Kind:    ChangeChanged,
+				Flag:    name,
+				Message: fmt.Sprintf("flag %q renamed to %q", name, renamed[name].Name),
+			})
+
+			d.flag(oldFlag, renamed[name])
+		default:
+			d.report(&Change{
+				Kind:     ChangeRemoved,
+				Flag:     name,
+				Message:  fmt.Sprintf("flag %q removed", name),
+				Breaking: true,
+			})
+		}
+	}
+}
+
+func (d *sourceDiff) added(flag *Flag, oldFlags map[string]*Flag) {
+	// renamed flags are compared with old flags
+	for _, name := range flag.RenamedFrom {
+		if oldFlags[name] != nil {
+			return
+		}
+	}
+
+	change := &Change{Kind: ChangeAdded, Flag: flag.Name, Message: fmt.Sprintf("flag %q of type %s added", flag.Name, flag.Type)}
+
+	if flag.Required {
+		change.Message = fmt.Sprintf("required flag %q of type %s added", flag.Name, flag.Type)
+		change.Breaking = true
+	}
+
+	d.report(change)
+}
+
+func (d *sourceDiff) flag(oldFlag, newFlag *Flag) {
+	name := newFlag.Name
+
+	if oldFlag.Type != newFlag.Type {
+		d.report(&Change{
+			Kind:     ChangeChanged,
+			Flag:     name,
+			Message:  fmt.Sprintf("type of flag %q changed from %s to %s", name, oldFlag.Type, newFlag.Type),
+			Breaking: true,
+		})
+	} else if oldFlag.CustomType != newFlag.CustomType {
+		d.report(&Change{
+			Kind:    ChangeChanged,
+			Flag:    name,
+			Message: fmt.Sprintf("Go type of flag %q changed from %s to %s", name, oldFlag.CustomType, newFlag.CustomType),
+		})
+	}
+
+	if !oldFlag.Required && newFlag.Required {
+		d.report(&Change{
+			Kind:     ChangeChanged,
+			Flag:     name,
+			Message:  fmt.Sprintf("flag %q became required", name),
+			Breaking: true,
+		})
+	}
+
+	if !oldFlag.IsDeprecated() && newFlag.IsDeprecated() {
+		d.report(&Change{Kind: ChangeChanged, Flag: name, Message: fmt.Sprintf("flag %q deprecated", name)})
+	}
+
+	d.names(oldFlag, newFlag)
+
+	if oldFlag.Type == newFlag.Type && oldFlag.CustomType == newFlag.CustomType {
+		d.enum(oldFlag, newFlag)
+		d.defaults(oldFlag, newFlag)
+	}
+}
+
+// names reports removed and added aliases and environment variables of the flag,
+// names of the old flag are accepted by the new flag if they are its old names.
+func (d *sourceDiff) names(oldFlag, newFlag *Flag) {
+	newNames := append(append([]string{newFlag.Name}, newFlag.Aliases...), newFlag.RenamedFrom...)
+
+	for _, alias := range missing(oldFlag.Aliases, newNames) {
+		d.report(&Change{
+			Kind:     ChangeRemoved,
+			Flag:     oldFlag.Name,
+			Message:  fmt.Sprintf("alias %q of flag %q removed", alias, oldFlag.Name),
+			Breaking: true,
+		})
+	}
+
+	for _, name := range missing(oldFlag.RenamedFrom, newNames) {
+		d.report(&Change{
+			Kind:     ChangeRemoved,
+			Flag:     oldFlag.Name,
+			Message:  fmt.Sprintf("old name %q of flag %q removed", name, oldFlag.Name),
+			Breaking: true,
+		})
+	}
+
+	for _, alias := range missing(newFlag.Aliases, append([]string{oldFlag.Name}, oldFlag.Aliases...)) {
+		d.report(&Change{
+			Kind:    ChangeAdded,
+			Flag:    newFlag.Name,
+			Message: fmt.Sprintf("alias %q of flag %q added", alias, newFlag.Name),
+		})
+	}
+
+	oldEnvVars := oldFlag.acceptedEnvVars(d.old.App.EnvVarPrefix())
+	newEnvVars := newFlag.acceptedEnvVars(d.new.App.EnvVarPrefix())
+
+	for _, name := range missing(oldEnvVars, newEnvVars) {
+		d.report(&Change{
+			Kind:     ChangeRemoved,
+			Flag:     oldFlag.Name,
+			Message:  fmt.Sprintf("environment variable %s of flag %q removed", name, oldFlag.Name),
+			Breaking: true,
+		})
+	}
+
+	for _, name := range missing(newFlag.EnvVars(d.new.App.EnvVarPrefix()), oldEnvVars) {
+		d.report(&Change{
+			Kind:    ChangeAdded,
+			Flag:    newFlag.Name,
+			Message: fmt.Sprintf("environment variable %s of flag %q added", name, newFlag.Name),
+		})
+	}
+}
+
+// acceptedEnvVars returns environment variables of the flag including environment variables of its old names.
+func (flag *Flag) acceptedEnvVars(prefix string) []string {
+	return append(flag.EnvVars(prefix), flag.renamedEnvVars(prefix)...)
+}
+
+func (d *sourceDiff) enum(oldFlag, newFlag *Flag) {
+	for _, variant := range missing(oldFlag.Enum, newFlag.Enum) {
+		d.report(&Change{
+			Kind:     ChangeRemoved,
+			Flag:     newFlag.Name,
+			Message:  fmt.Sprintf("enum variant %q of flag %q removed", variant, newFlag.Name),
+			Breaking: true,
+		})
+	}
+
+	for _, variant := range missing(newFlag.Enum, oldFlag.Enum) {
+		d.report(&Change{
+			Kind:    ChangeAdded,
+			Flag:    newFlag.Name,
+			Message: fmt.Sprintf("enum variant %q of flag %q added", variant, newFlag.Name),
+		})
+	}
+}
+
+// defaults reports changed default values of environments of both sources,
+// values are compared as they are generated by Args.
+func (d *sourceDiff) defaults(oldFlag, newFlag *Flag) {
+	// constants of enum values are named by the Go name of the flag
+	renamedFlag := *oldFlag
+	renamedFlag.GoName = newFlag.GoIdent()
+	oldFlag = &renamedFlag
+
+	oldEnvs := make(map[string]bool, len(d.old.App.Env))
+	for _, env := range d.old.App.Env {
+		oldEnvs[env.String()] = true
+	}
+
+	for _, env := range d.new.App.Env {
+		if !oldEnvs[env.String()] {
+			continue
+		}
+
+		oldArgs, err := oldFlag.checkedArgs(env.String())
+		if err != nil {
+			d.fail(errors.Wrapf(err, "old default of flag %q in %s", oldFlag.Name, env))
+
+			return
+		}
+
+		newArgs, err := newFlag.checkedArgs(env.String())
+		if err != nil {
+			d.fail(errors.Wrapf(err, "new default of flag %q in %s", newFlag.Name, env))
+
+			return
+		}
+
+		if oldArgs == newArgs {
+			continue
+		}
+
+		change := &Change{
+			Kind:    ChangeChanged,
+			Flag:    newFlag.Name,
+			Env:     env.String(),
+			Message: fmt.Sprintf("default of secret flag %q in %s changed", newFlag.Name, env),
+		}
+
+		if !oldFlag.Secret && !newFlag.Secret {
+			change.Message = fmt.Sprintf("default of flag %q in %s changed from %s to %s", newFlag.Name, env,
+				formatDiffValue(oldFlag.defaultValue(env.String())), formatDiffValue(newFlag.defaultValue(env.String())))
+		}
+
+		d.report(change)
+	}
+}
+
+// fail records the first error of the diff.
+func (d *sourceDiff) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// checkedArgs returns Args of the flag and an error instead of the panic of invalid values.
+func (flag *Flag) checkedArgs(env string) (args string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("%v", r)
+		}
+	}()
+
+	return flag.Args(env), nil
+}
+
+// formatDiffValue formats the value of the source as YAML flow.
+func formatDiffValue(value interface{}) string {
+	if value == nil {
+		return "none"
+	}
+
+	var node yaml.Node
+
+	if err := node.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+
+	setFlowStyle(&node)
+
+	b, err := yaml.Marshal(&node)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return strings.TrimSpace(string(b))
+}
+
+func setFlowStyle(node *yaml.Node) {
+	if node.Kind == yaml.SequenceNode || node.Kind == yaml.MappingNode {
+		node.Style = yaml.FlowStyle
+	}
+
+	for _, child := range node.Content {
+		setFlowStyle(child)
+	}
+}
+
+// missing returns names which are not contained in other.
+func missing(names, other []string) []string {
+	contained := make(map[string]bool, len(other))
+	for _, name := range other {
+		contained[name] = true
+	}
+
+	var result []string
+
+	for _, name := range names {
+		if !contained[name] {
+			result = append(result, name)
+			contained[name] = true
+		}
+	}
+
+	return result
+}
+
+// writeDiffReport writes changes in the format.
+func writeDiffReport(w io.Writer, format string, changes []*Change) error {
+	switch format {
+	case DiffFormatText, "":
+		for _, change := range changes {
+			if _, err := fmt.Fprintln(w, change); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case DiffFormatJSON:
+		if changes == nil {
+			changes = []*Change{}
+		}
+
+		return writeJSON(w, changes)
+	default:
+		return errors.Errorf("unsupported diff format %q, expected text or json", format)
+	}
+}
@@ -0,0 +1,104 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff_Run(t *testing.T) {
+	for _, format := range []string{DiffFormatText, DiffFormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			diff := &Diff{
+				Old:    filepath.Join("testdata", "diff", "old.yaml"),
+				New:    filepath.Join("testdata", "diff", "new.yaml"),
+				Format: format,
+			}
+
+			var buf bytes.Buffer
+
+			assert.EqualError(t, diff.Run(&buf), "8 breaking changes found")
+			assertGolden(t, filepath.Join("testdata", "diff", "changes."+format), buf.Bytes())
+		})
+	}
+}
+
+func TestDiff_Run_exitCode(t *testing.T) {
+	diff := &Diff{
+		Old: filepath.Join("testdata", "golden", "env.yaml"),
+		New: filepath.Join("testdata", "golden", "env.yaml"),
+	}
+
+	var buf bytes.Buffer
+
+	assert.NoError(t, diff.Run(&buf))
+	assert.Empty(t, buf.String())
+
+	diff.New = filepath.Join("testdata", "diff", "env.yaml")
+	assert.NoError(t, diff.Run(&buf))
+	assert.Equal(t, `+ alias "p" of flag "port" added
+~ default of flag "port" in dev changed from 8080 to 9090
+`, buf.String())
+
+	diff.ExitCode = true
+	assert.EqualError(t, diff.Run(&buf), "2 changes found")
+}
+
+func TestDiff_Changes_invalidDefault(t *testing.T) {
+	dir := t.TempDir()
+	source := "app:\n  name: app\n  env: [ local ]\nflags:\n  since:\n    type: timestamp\n    value: %s\n"
+
+	oldPath, newPath := filepath.Join(dir, "old.yaml"), filepath.Join(dir, "new.yaml")
+	assert.NoError(t, os.WriteFile(oldPath, []byte(fmt.Sprintf(source, "2024-01-31T00:00:00Z")), 0o644))
+	assert.NoError(t, os.WriteFile(newPath, []byte(fmt.Sprintf(source, "yesterday")), 0o644))
+
+	_, err := (&Diff{Old: oldPath, New: newPath}).Changes()
+	assert.ErrorContains(t, err, `new default of flag "since" in local: timestampArg: parsing time "yesterday"`)
+}
+
+func TestGitShow(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("committed"), 0o644))
+	run("init", "-q")
+	run("add", "config.yaml")
+	run("commit", "-q", "-m", "init")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("changed"), 0o644))
+
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(filepath.Join(dir, "sub")))
+
+	t.Cleanup(func() {
+		assert.NoError(t, os.Chdir(wd))
+	})
+
+	for _, version := range []string{"HEAD:../config.yaml", "HEAD:" + filepath.Join(dir, "config.yaml")} {
+		content, err := gitShow(version)
+		assert.NoError(t, err, version)
+		assert.Equal(t, "committed", string(content), version)
+	}
+
+	_, err = gitShow("HEAD:" + filepath.Join(filepath.Dir(dir), "config.yaml"))
+	assert.ErrorContains(t, err, "is outside of git repository")
+
+	// revision is not parsed as an option
+	_, err = gitShow("--output=out:" + filepath.Join(dir, "config.yaml"))
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "sub", "out:config.yaml"))
+}
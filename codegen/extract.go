@@ -0,0 +1,1098 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	config "github.com/partyzanex/cli-config-gen"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Extract writes the source config file reconstructed from config.go generated for cli/v2 by the built-in template,
+// generated code of the written source is the same as the read one.
+type Extract struct {
+	// GoFile is the path of the generated file.
+	GoFile string
+	// SourceFile is the path of the written source config file.
+	SourceFile string
+	// Force enables overwriting of the existing source file.
+	Force bool
+}
+
+func (e *Extract) Run() error {
+	if _, err := os.Stat(e.SourceFile); err == nil && !e.Force {
+		return errors.Errorf("source file %s already exists", e.SourceFile)
+	}
+
+	source, err := extractSource(e.GoFile)
+	if err != nil {
+		return err
+	}
+
+	content, err := encodeSource("Extracted from "+e.GoFile+" by cli-config-gen extract.", source)
+	if err != nil {
+		return err
+	}
+
+	err = yaml.Unmarshal(content, new(Source))
+	if err != nil {
+		return errors.Wrap(err, "invalid source")
+	}
+
+	return writeFiles([]*generatedFile{{path: e.SourceFile, content: content}})
+}
+
+const (
+	cliV2Path       = "github.com/urfave/cli/v2"
+	envFlagConst    = "EnvFlagName"
+	printFlagConst  = "PrintConfigFlagName"
+	flagNameSuffix  = "FlagName"
+	envResolverName = "envResolver"
+)
+
+// valueFlagTypes maps ValueType of flags to flag types, enums are told apart from strings by enum constants.
+var valueFlagTypes = map[string]FlagType{
+	enumValueTypeString:        FlagTypeString,
+	enumValueTypeInt:           FlagTypeInt,
+	enumValueTypeInt32:         FlagTypeInt32,
+	enumValueTypeInt64:         FlagTypeInt64,
+	enumValueTypeUint:          FlagTypeUInt,
+	enumValueTypeUint32:        FlagTypeUInt32,
+	enumValueTypeUint64:        FlagTypeUInt64,
+	enumValueTypeFloat32:       FlagTypeFloat32,
+	enumValueTypeFloat64:       FlagTypeFloat64,
+	enumValueTypeBytes:         FlagTypeBytes,
+	enumValueTypeBool:          FlagTypeBool,
+	enumValueTypeTimestamp:     FlagTypeTimestamp,
+	enumValueTypeDuration:      FlagTypeDuration,
+	enumValueTypeStringSlice:   FlagTypeStringSlice,
+	enumValueTypeIntSlice:      FlagTypeIntSlice,
+	enumValueTypeInt64Slice:    FlagTypeInt64Slice,
+	enumValueTypeUintSlice:     FlagTypeUIntSlice,
+	enumValueTypeUint64Slice:   FlagTypeUInt64Slice,
+	enumValueTypeFloat64Slice:  FlagTypeFloat64Slice,
+	enumValueTypeBoolSlice:     FlagTypeBoolSlice,
+	enumValueTypeDurationSlice: FlagTypeDurationSlice,
+	enumValueTypeEnumSlice:     FlagTypeEnumSlice,
+	enumValueTypeURL:           FlagTypeURL,
+	enumValueTypeHostPort:      FlagTypeHostPort,
+	enumValueTypeIP:            FlagTypeIP,
+	enumValueTypeIPSlice:       FlagTypeIPSlice,
+	enumValueTypeCIDRSlice:     FlagTypeCIDRSlice,
+	enumValueTypeStringMap:     FlagTypeStringMap,
+	enumValueTypeIntMap:        FlagTypeIntMap,
+	enumValueTypeInt64Map:      FlagTypeInt64Map,
+	enumValueTypeFloat64Map:    FlagTypeFloat64Map,
+	"Text":                     FlagTypeCustom,
+}
+
+var enumsDocRe = regexp.MustCompile(`^(\w+) enums$`)
+
+// extractor collects declarations of generated file.
+type extractor struct {
+	fset *token.FileSet
+	// consts contains string constants by name.
+	consts map[string]string
+	// envConsts contains names of environment constants in order of declaration.
+	envConsts []string
+	// enums contains enum variants by Go name of flag.
+	enums map[string][]string
+	// imports contains import paths by package name.
+	imports map[string]string
+	// values contains calls of value setters by Go name of flag.
+	values map[string][]*ast.CallExpr
+	// flagFuncs contains functions returning flags by Go name of flag.
+	flagFuncs map[string]*ast.FuncDecl
+	// secrets contains Go names of secret flags.
+	secrets map[string]bool
+	// requiredRenamed contains Go names of required renamed flags, see RequiredRenamed.
+	requiredRenamed map[string]bool
+	resolver        *ast.CompositeLit
+}
+
+// extractSource parses the generated file and reconstructs its source.
+func extractSource(filename string) (*Source, error) {
+	e := &extractor{
+		fset:            token.NewFileSet(),
+		consts:          make(map[string]string),
+		enums:           make(map[string][]string),
+		imports:         make(map[string]string),
+		values:          make(map[string][]*ast.CallExpr),
+		flagFuncs:       make(map[string]*ast.FuncDecl),
+		secrets:         make(map[string]bool),
+		requiredRenamed: make(map[string]bool),
+	}
+
+	file, err := parser.ParseFile(e.fset, filename, nil, parser.ParseComments)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse generated file")
+	}
+
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+
+		name := path.Base(importPath)
+		if versionSuffixRe.MatchString(name) {
+			name = path.Base(path.Dir(importPath))
+		}
+
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		e.imports[name] = importPath
+	}
+
+	if e.imports["cli"] != cliV2Path {
+		return nil, errors.Errorf("%s is not generated for %s, only the built-in cli/v2 template is supported", filename, TargetLibCLIv2)
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			e.collectDecl(decl)
+		case *ast.FuncDecl:
+			e.collectFunc(decl)
+		}
+	}
+
+	source := new(Source)
+
+	source.App, err = e.app()
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range e.consts {
+		if !strings.HasSuffix(name, flagNameSuffix) || name == envFlagConst || name == printFlagConst {
+			continue
+		}
+
+		flag, err := e.flag(strings.TrimSuffix(name, flagNameSuffix), value, &source.App)
+		if err != nil {
+			return nil, err
+		}
+
+		source.Flags = append(source.Flags, flag)
+	}
+
+	sort.Slice(source.Flags, func(i, j int) bool {
+		return source.Flags[i].Name < source.Flags[j].Name
+	})
+
+	return source, nil
+}
+
+func (e *extractor) collectDecl(decl *ast.GenDecl) {
+	var enumOf string
+	if m := enumsDocRe.FindStringSubmatch(strings.TrimSpace(decl.Doc.Text())); m != nil {
+		enumOf = m[1]
+	}
+
+	for _, spec := range decl.Specs {
+		spec, ok := spec.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || len(spec.Values) != 1 {
+			continue
+		}
+
+		name := spec.Names[0].Name
+
+		switch decl.Tok {
+		case token.CONST:
+			value, ok := stringLit(spec.Values[0])
+			if !ok {
+				continue
+			}
+
+			e.consts[name] = value
+
+			if ident, ok := spec.Type.(*ast.Ident); ok && ident.Name == "EnvName" {
+				e.envConsts = append(e.envConsts, name)
+			}
+
+			if enumOf != "" {
+				e.enums[enumOf] = append(e.enums[enumOf], value)
+			}
+		case token.VAR:
+			if name == envResolverName {
+				e.resolver, _ = unaryCompositeLit(spec.Values[0])
+
+				continue
+			}
+
+			if calls, ok := setterCalls(spec.Values[0]); ok {
+				e.values[name] = calls
+			}
+		}
+	}
+}
+
+func (e *extractor) collectFunc(decl *ast.FuncDecl) {
+	name := decl.Name.Name
+
+	switch {
+	case decl.Recv != nil:
+		// not generated
+	case name == "PrintConfig":
+		ast.Inspect(decl.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || types.ExprString(call.Fun) != "NewConfigEntry" || len(call.Args) < 4 {
+				return true
+			}
+
+			variable, ok := call.Args[1].(*ast.Ident)
+			if secret, _ := boolLit(call.Args[3]); ok && secret {
+				e.secrets[strings.TrimSuffix(variable.Name, flagNameSuffix)] = true
+			}
+
+			return false
+		})
+	case name == "CLIFlags":
+		ast.Inspect(decl.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || types.ExprString(call.Fun) != "RequiredRenamed" || len(call.Args) == 0 {
+				return true
+			}
+
+			if variable, ok := call.Args[0].(*ast.Ident); ok {
+				e.requiredRenamed[strings.TrimSuffix(variable.Name, flagNameSuffix)] = true
+			}
+
+			return false
+		})
+	case name == "EnvFlag", name == "PrintConfigFlag":
+		// built-in flags
+	case strings.HasSuffix(name, "Flag"):
+		e.flagFuncs[strings.TrimSuffix(name, "Flag")] = decl
+	}
+}
+
+// setterCalls returns calls of value setters of NewValue(Env) chain in order of calls.
+func setterCalls(expr ast.Expr) ([]*ast.CallExpr, bool) {
+	var calls []*ast.CallExpr
+
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return nil, false
+		}
+
+		if types.ExprString(call.Fun) == "NewValue" {
+			break
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !strings.HasPrefix(sel.Sel.Name, "Set") {
+			return nil, false
+		}
+
+		calls = append([]*ast.CallExpr{call}, calls...)
+		expr = sel.X
+	}
+
+	return calls, true
+}
+
+func (e *extractor) app() (App, error) {
+	app := App{
+		Name:        e.consts["AppName"],
+		Desc:        e.consts["AppDesc"],
+		PrintConfig: e.consts[printFlagConst] != "",
+	}
+
+	if e.resolver == nil {
+		return app, errors.New("envResolver is not declared")
+	}
+
+	fields := keyValues(e.resolver)
+
+	byConst := make(map[string]*Environment)
+
+	for _, name := range e.envConsts {
+		byConst[name] = &Environment{Name: config.EnvName(e.consts[name])}
+	}
+
+	envs, ok := fields["Envs"].(*ast.CompositeLit)
+	if !ok {
+		return app, e.errorf(e.resolver, "unsupported Envs of envResolver")
+	}
+
+	for _, elt := range envs.Elts {
+		env, ok := byConst[types.ExprString(elt)]
+		if !ok {
+			return app, e.errorf(elt, "unknown environment %s", types.ExprString(elt))
+		}
+
+		app.Env = append(app.Env, env)
+	}
+
+	if aliases, ok := fields["Aliases"].(*ast.CompositeLit); ok {
+		for _, elt := range keyValueExprs(aliases) {
+			alias, _ := stringLit(elt.Key)
+
+			env, ok := byConst[types.ExprString(elt.Value)]
+			if !ok {
+				return app, e.errorf(elt, "unknown environment %s", types.ExprString(elt.Value))
+			}
+
+			env.Aliases = append(env.Aliases, alias)
+		}
+	}
+
+	app.EnvIgnoreCase, _ = boolLit(fields["IgnoreCase"])
+
+	key, ok := stringLit(fields["Key"])
+	if !ok || !strings.HasSuffix(key, "ENV") {
+		return app, e.errorf(e.resolver, "unsupported Key of envResolver")
+	}
+
+	if key != app.EnvKey() {
+		prefix := strings.TrimSuffix(strings.TrimSuffix(key, "ENV"), "_")
+		app.EnvPrefix = &prefix
+	}
+
+	return app, nil
+}
+
+// flag reconstructs the flag named name with Go name variable.
+func (e *extractor) flag(variable, name string, app *App) (*Flag, error) {
+	decl, ok := e.flagFuncs[variable]
+	if !ok {
+		return nil, errors.Errorf("function %sFlag of flag %q is not declared", variable, name)
+	}
+
+	lit := flagLit(decl)
+	if lit == nil {
+		return nil, e.errorf(decl, "%sFlag doesn't return a flag literal", variable)
+	}
+
+	fields := keyValues(lit)
+
+	flag := &Flag{
+		Name:   name,
+		Secret: e.secrets[variable],
+	}
+
+	if variable != strcase.ToCamel(name) {
+		flag.GoName = variable
+	}
+
+	err := e.flagType(flag, variable, lit, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	flag.Aliases, _ = stringsLit(fields["Aliases"])
+	flag.Required, _ = boolLit(fields["Required"])
+	flag.Required = flag.Required || e.requiredRenamed[variable]
+	flag.Hidden, _ = boolLit(fields["Hidden"])
+	flag.TakesFile, _ = boolLit(fields["TakesFile"])
+	flag.DefaultText, _ = stringLit(fields["DefaultText"])
+
+	if flag.Secret && flag.DefaultText == redactedText {
+		// redacted default of secret flag is generated
+		flag.DefaultText = ""
+	}
+
+	renamedEnvVars := e.deprecation(flag, fields["Action"])
+
+	envVars, _ := stringsLit(fields["EnvVars"])
+	if flag.Required {
+		envVars = trimSuffix(envVars, renamedEnvVars)
+	}
+
+	flag.setEnvVars(envVars, app.EnvVarPrefix())
+
+	usage, _ := stringLit(fields["Usage"])
+	flag.Desc = flag.descFromField(usage)
+
+	err = e.flagValue(flag, variable)
+	if err != nil {
+		return nil, err
+	}
+
+	return flag, nil
+}
+
+// flagType sets type of the flag by type of flag literal and its Value.
+func (e *extractor) flagType(flag *Flag, variable string, lit *ast.CompositeLit, fields map[string]ast.Expr) error {
+	valueType := strings.TrimSuffix(strings.TrimPrefix(types.ExprString(lit.Type), "cli."), "Flag")
+
+	if valueType == "Generic" {
+		call, ok := fields["Value"].(*ast.CallExpr)
+		if !ok {
+			return e.errorf(lit, "unsupported Value of generic flag %sFlag", variable)
+		}
+
+		valueType = strings.TrimSuffix(strings.TrimPrefix(types.ExprString(call.Fun), "New"), "Value")
+
+		if valueType == "Text" {
+			goType, err := e.customType(call)
+			if err != nil {
+				return err
+			}
+
+			flag.CustomType = goType
+		}
+	}
+
+	flagType, ok := valueFlagTypes[valueType]
+	if !ok {
+		return e.errorf(lit, "unsupported flag type %s of %sFlag", valueType, variable)
+	}
+
+	flag.Type = flagType
+
+	if enum, ok := e.enums[variable]; ok && (flagType == FlagTypeString || flagType == FlagTypeEnumSlice) {
+		flag.Type = FlagTypeEnum
+		if flagType == FlagTypeEnumSlice {
+			flag.Type = FlagTypeEnumSlice
+		}
+
+		flag.Enum = enum
+	}
+
+	if flag.Type == FlagTypeTimestamp {
+		flag.Layout = e.layout(fields["Layout"])
+		flag.Timezone = e.timezone(fields["Timezone"])
+	}
+
+	return nil
+}
+
+// customType returns goType of custom flag from NewTextValue(ValueOf[pkg.Type](Variable)).
+func (e *extractor) customType(call *ast.CallExpr) (string, error) {
+	if len(call.Args) == 1 {
+		if valueOf, ok := call.Args[0].(*ast.CallExpr); ok {
+			if index, ok := valueOf.Fun.(*ast.IndexExpr); ok {
+				if sel, ok := index.Index.(*ast.SelectorExpr); ok {
+					if importPath, ok := e.imports[types.ExprString(sel.X)]; ok {
+						return importPath + "." + sel.Sel.Name, nil
+					}
+				}
+			}
+		}
+	}
+
+	return "", e.errorf(call, "unsupported Value of custom flag")
+}
+
+func (e *extractor) layout(expr ast.Expr) string {
+	if s, ok := stringLit(expr); ok {
+		return s
+	}
+
+	layout := strings.TrimPrefix(types.ExprString(expr), "time.")
+	if layout == "RFC3339" {
+		return ""
+	}
+
+	return layout
+}
+
+func (e *extractor) timezone(expr ast.Expr) string {
+	switch s := types.ExprString(expr); s {
+	case "time.UTC":
+		return ""
+	case "time.Local":
+		return "Local"
+	default:
+		if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+			tz, _ := stringLit(call.Args[0])
+
+			return tz
+		}
+
+		return ""
+	}
+}
+
+// deprecation sets deprecation attributes of the flag from warnings of its Action
+// and returns environment variables of old names.
+func (e *extractor) deprecation(flag *Flag, action ast.Expr) []string {
+	var renamedEnvVars []string
+
+	if action == nil {
+		return nil
+	}
+
+	ast.Inspect(action, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		switch types.ExprString(call.Fun) {
+		case "WarnRenamed":
+			if len(call.Args) == 5 {
+				flag.RenamedFrom, _ = stringsLit(call.Args[1])
+				renamedEnvVars, _ = stringsLit(call.Args[3])
+				flag.RemoveAfter, _ = stringLit(call.Args[4])
+			}
+		case "WarnDeprecated":
+			if len(call.Args) == 3 {
+				flag.Deprecated, _ = stringLit(call.Args[1])
+				flag.RemoveAfter, _ = stringLit(call.Args[2])
+			}
+		}
+
+		return true
+	})
+
+	return renamedEnvVars
+}
+
+// setEnvVars sets env attributes of the flag which produce environment variables envVars.
+func (flag *Flag) setEnvVars(envVars []string, prefix string) {
+	if len(envVars) == 0 {
+		flag.Env = false
+
+		return
+	}
+
+	if envVars[0] == prefix+strcase.ToScreamingSnake(flag.Name) {
+		envVars = envVars[1:]
+	} else {
+		primary := false
+		flag.EnvPrimary = &primary
+	}
+
+	var env []interface{}
+
+	for i, name := range envVars {
+		kebab := strcase.ToKebab(name)
+		if strcase.ToScreamingSnake(kebab) != name {
+			flag.EnvExact = envVars[i:]
+
+			break
+		}
+
+		env = append(env, kebab)
+	}
+
+	if len(env) > 0 {
+		flag.Env = env
+	}
+}
+
+// descFromField returns the description of the flag which is shown as usage, see DescField.
+func (flag *Flag) descFromField(usage string) string {
+	if flag.IsDeprecated() {
+		deprecated := "DEPRECATED: " + flag.Deprecated
+		if flag.RemoveAfter != "" {
+			deprecated += ", will be removed after " + flag.RemoveAfter
+		}
+
+		usage = strings.TrimSpace(strings.TrimSuffix(usage, "("+deprecated+")"))
+	}
+
+	if flag.Type == FlagTypeEnum || flag.Type == FlagTypeEnumSlice {
+		variants := strings.Join(flag.Enum, ", ")
+		if usage == "variants: "+variants {
+			return ""
+		}
+
+		usage = strings.TrimSuffix(usage, ", (variants: "+variants+")")
+	}
+
+	return usage
+}
+
+// flagValue sets values of the flag from setter calls of its value variable,
+// values equal in all environments are written once.
+func (e *extractor) flagValue(flag *Flag, variable string) error {
+	calls, ok := e.values[variable]
+	if !ok {
+		return errors.Errorf("values of flag %q are not declared", flag.Name)
+	}
+
+	var (
+		envs   = make([]string, 0, len(calls))
+		values = make([]interface{}, 0, len(calls))
+	)
+
+	for _, call := range calls {
+		if len(call.Args) == 0 {
+			return e.errorf(call, "environment of value of flag %q is not set", flag.Name)
+		}
+
+		env, ok := e.consts[types.ExprString(call.Args[0])]
+		if !ok {
+			return e.errorf(call, "unknown environment %s", types.ExprString(call.Args[0]))
+		}
+
+		value, err := e.value(flag, call.Args[1:])
+		if err != nil {
+			return err
+		}
+
+		envs = append(envs, env)
+		values = append(values, value)
+	}
+
+	same := true
+	for _, value := range values {
+		same = same && reflect.DeepEqual(value, values[0])
+	}
+
+	if same && len(values) > 0 {
+		flag.Value = values[0]
+
+		return nil
+	}
+
+	perEnv := make(map[string]interface{})
+
+	for i, value := range values {
+		if value != nil {
+			perEnv[envs[i]] = value
+		}
+	}
+
+	if flag.IsMap() {
+		flag.Values = perEnv
+	} else {
+		flag.Value = perEnv
+	}
+
+	return nil
+}
+
+// value returns the value of the flag passed to its setter by args,
+// it's nil if the value is zero and it's omitted in source.
+func (e *extractor) value(flag *Flag, args []ast.Expr) (interface{}, error) {
+	if flag.IsSlice() && flag.Type != FlagTypeEnumSlice {
+		return e.sliceValue(flag, args)
+	}
+
+	if len(args) != 1 {
+		return nil, e.errorf(args[0], "unexpected arguments of value setter of flag %q", flag.Name)
+	}
+
+	arg := args[0]
+
+	value, err := e.scalarValue(flag, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == nil && types.ExprString(arg) != nilStr && !isZeroLit(arg) {
+		return nil, e.errorf(arg, "unsupported value %s of flag %q", types.ExprString(arg), flag.Name)
+	}
+
+	return value, nil
+}
+
+// isZeroLit reports whether expr is a literal of zero value written by Flag.Args.
+func isZeroLit(expr ast.Expr) bool {
+	if lit, ok := expr.(*ast.CompositeLit); ok {
+		return len(lit.Elts) == 0
+	}
+
+	if s, ok := stringLit(expr); ok {
+		return s == ""
+	}
+
+	if b, ok := boolLit(expr); ok {
+		return !b
+	}
+
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return false
+	}
+
+	n, ok := numberLit(call.Args[0])
+	if !ok {
+		return false
+	}
+
+	f, err := strconv.ParseFloat(n, 64)
+
+	return err == nil && f == 0
+}
+
+func (e *extractor) scalarValue(flag *Flag, arg ast.Expr) (interface{}, error) {
+	switch flag.Type {
+	case FlagTypeString, FlagTypeHostPort:
+		if s, ok := stringLit(arg); ok && s != "" {
+			return s, nil
+		}
+	case FlagTypeBool:
+		if b, ok := boolLit(arg); ok && b {
+			return true, nil
+		}
+	case FlagTypeInt, FlagTypeInt32, FlagTypeInt64, FlagTypeUInt, FlagTypeUInt32, FlagTypeUInt64, FlagTypeBytes:
+		n, ok := conversionArg(arg)
+		if !ok {
+			break
+		}
+
+		return intValue(flag, n)
+	case FlagTypeFloat32, FlagTypeFloat64:
+		if n, ok := conversionArg(arg); ok {
+			f, err := strconv.ParseFloat(n, 64)
+			if err == nil && f != 0 {
+				return f, nil
+			}
+		}
+	case FlagTypeDuration:
+		if n, ok := conversionArg(arg); ok {
+			d, err := strconv.ParseInt(n, 10, 64)
+			if err == nil && d != 0 {
+				return time.Duration(d).String(), nil
+			}
+		}
+	case FlagTypeTimestamp:
+		return e.timestampValue(flag, arg)
+	case FlagTypeEnum:
+		if variant, ok := e.consts[types.ExprString(arg)]; ok {
+			return variant, nil
+		}
+
+		return nil, e.errorf(arg, "unknown variant %s of flag %q", types.ExprString(arg), flag.Name)
+	case FlagTypeEnumSlice:
+		return e.enumSliceValue(flag, arg)
+	case FlagTypeURL, FlagTypeIP, FlagTypeCustom:
+		if call, ok := arg.(*ast.CallExpr); ok && len(call.Args) == 1 {
+			if s, ok := stringLit(call.Args[0]); ok {
+				return s, nil
+			}
+		}
+	case FlagTypeStringMap, FlagTypeIntMap, FlagTypeInt64Map, FlagTypeFloat64Map:
+		return e.mapValue(flag, arg)
+	}
+
+	return nil, nil
+}
+
+func intValue(flag *Flag, n string) (interface{}, error) {
+	i, err := strconv.ParseInt(n, 10, 64)
+	if err != nil {
+		u, err := strconv.ParseUint(n, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid integer %s of flag %q", n, flag.Name)
+		}
+
+		return u, nil
+	}
+
+	switch {
+	case i == 0:
+		return nil, nil
+	case flag.Type == FlagTypeBytes:
+		if size, err := config.ParseBytes(config.FormatBytes(uint64(i))); err == nil && size == uint64(i) {
+			return config.FormatBytes(uint64(i)), nil
+		}
+	}
+
+	return int(i), nil
+}
+
+func (e *extractor) timestampValue(flag *Flag, arg ast.Expr) (interface{}, error) {
+	call, ok := arg.(*ast.CallExpr)
+	if !ok {
+		return nil, nil
+	}
+
+	// the instant of the value with offset is converted to the local timezone by In
+	instant := false
+
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "In" {
+		if inner, ok := sel.X.(*ast.CallExpr); ok {
+			call, instant = inner, true
+		}
+	}
+
+	if types.ExprString(call.Fun) != "time.Date" || len(call.Args) != 8 {
+		return nil, e.errorf(arg, "unsupported timestamp %s of flag %q", types.ExprString(arg), flag.Name)
+	}
+
+	var parts [7]int
+
+	for i := range parts {
+		n, ok := numberLit(call.Args[i])
+		if !ok {
+			return nil, e.errorf(call.Args[i], "unsupported timestamp %s of flag %q", types.ExprString(arg), flag.Name)
+		}
+
+		parts[i], _ = strconv.Atoi(n)
+	}
+
+	loc := time.UTC
+
+	if flag.Timezone != "" && !instant {
+		var err error
+
+		loc, err = time.LoadLocation(flag.Timezone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid timezone of flag %q", flag.Name)
+		}
+	}
+
+	dt := time.Date(parts[0], time.Month(parts[1]), parts[2], parts[3], parts[4], parts[5], parts[6], loc)
+
+	return dt.Format(flag.layout()), nil
+}
+
+func (e *extractor) enumSliceValue(flag *Flag, arg ast.Expr) (interface{}, error) {
+	lit, ok := arg.(*ast.CompositeLit)
+	if !ok {
+		return nil, nil
+	}
+
+	var values []interface{}
+
+	for _, elt := range lit.Elts {
+		variant, ok := e.consts[types.ExprString(elt)]
+		if !ok {
+			return nil, e.errorf(elt, "unknown variant %s of flag %q", types.ExprString(elt), flag.Name)
+		}
+
+		values = append(values, variant)
+	}
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	return values, nil
+}
+
+func (e *extractor) sliceValue(flag *Flag, args []ast.Expr) (interface{}, error) {
+	var values []interface{}
+
+	for _, arg := range args {
+		var (
+			value interface{}
+			err   error
+		)
+
+		switch flag.Type {
+		case FlagTypeStringSlice:
+			value, _ = stringLit(arg)
+		case FlagTypeBoolSlice:
+			value, _ = boolLit(arg)
+		case FlagTypeIntSlice, FlagTypeInt64Slice, FlagTypeUIntSlice, FlagTypeUInt64Slice:
+			if n, ok := numberLit(arg); ok {
+				value, err = intValue(flag, n)
+				if value == nil {
+					value = 0
+				}
+			}
+		case FlagTypeFloat64Slice:
+			if n, ok := numberLit(arg); ok {
+				value, err = strconv.ParseFloat(n, 64)
+			}
+		case FlagTypeDurationSlice:
+			if n, ok := conversionArg(arg); ok {
+				var d int64
+
+				d, err = strconv.ParseInt(n, 10, 64)
+				value = time.Duration(d).String()
+			}
+		case FlagTypeIPSlice, FlagTypeCIDRSlice:
+			if call, ok := arg.(*ast.CallExpr); ok && len(call.Args) == 1 {
+				value, _ = stringLit(call.Args[0])
+			}
+		}
+
+		if err != nil || value == nil {
+			return nil, e.errorf(arg, "unsupported value %s of flag %q", types.ExprString(arg), flag.Name)
+		}
+
+		values = append(values, value)
+	}
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	return values, nil
+}
+
+func (e *extractor) mapValue(flag *Flag, arg ast.Expr) (interface{}, error) {
+	lit, ok := arg.(*ast.CompositeLit)
+	if !ok {
+		return nil, nil
+	}
+
+	m := make(map[string]interface{})
+
+	for _, elt := range keyValueExprs(lit) {
+		key, _ := stringLit(elt.Key)
+
+		var value interface{}
+
+		if s, ok := stringLit(elt.Value); ok && flag.Type == FlagTypeStringMap {
+			value = s
+		} else if n, ok := numberLit(elt.Value); ok && flag.Type == FlagTypeFloat64Map {
+			value, _ = strconv.ParseFloat(n, 64)
+		} else if n, ok := numberLit(elt.Value); ok {
+			value, _ = strconv.Atoi(n)
+		} else {
+			return nil, e.errorf(elt, "unsupported value %s of flag %q", types.ExprString(elt.Value), flag.Name)
+		}
+
+		m[key] = value
+	}
+
+	return m, nil
+}
+
+func (e *extractor) errorf(node ast.Node, format string, args ...interface{}) error {
+	return errors.Errorf("%s: %s", e.fset.Position(node.Pos()), fmt.Sprintf(format, args...))
+}
+
+// flagLit returns the flag literal returned by function of the flag.
+func flagLit(decl *ast.FuncDecl) *ast.CompositeLit {
+	if decl.Body == nil {
+		return nil
+	}
+
+	for _, stmt := range decl.Body.List {
+		if ret, ok := stmt.(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+			result := ret.Results[0]
+
+			// generic slice and map flags are wrapped by ArgsOverrideEnv
+			if call, ok := result.(*ast.CallExpr); ok && types.ExprString(call.Fun) == "ArgsOverrideEnv" && len(call.Args) == 1 {
+				result = call.Args[0]
+			}
+
+			lit, _ := unaryCompositeLit(result)
+
+			return lit
+		}
+	}
+
+	return nil
+}
+
+func unaryCompositeLit(expr ast.Expr) (*ast.CompositeLit, bool) {
+	unary, ok := expr.(*ast.UnaryExpr)
+	if !ok || unary.Op != token.AND {
+		return nil, false
+	}
+
+	lit, ok := unary.X.(*ast.CompositeLit)
+
+	return lit, ok
+}
+
+func keyValueExprs(lit *ast.CompositeLit) []*ast.KeyValueExpr {
+	var kvs []*ast.KeyValueExpr
+
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			kvs = append(kvs, kv)
+		}
+	}
+
+	return kvs
+}
+
+// keyValues returns fields of struct literal by names.
+func keyValues(lit *ast.CompositeLit) map[string]ast.Expr {
+	fields := make(map[string]ast.Expr)
+
+	for _, kv := range keyValueExprs(lit) {
+		fields[types.ExprString(kv.Key)] = kv.Value
+	}
+
+	return fields
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+
+	s, err := strconv.Unquote(lit.Value)
+
+	return s, err == nil
+}
+
+// stringsLit returns elements of []string literal, nil literal is an empty slice.
+func stringsLit(expr ast.Expr) ([]string, bool) {
+	if types.ExprString(expr) == nilStr {
+		return nil, true
+	}
+
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil, false
+	}
+
+	ss := make([]string, 0, len(lit.Elts))
+
+	for _, elt := range lit.Elts {
+		s, ok := stringLit(elt)
+		if !ok {
+			return nil, false
+		}
+
+		ss = append(ss, s)
+	}
+
+	return ss, true
+}
+
+func boolLit(expr ast.Expr) (bool, bool) {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return false, false
+	}
+
+	b, err := strconv.ParseBool(ident.Name)
+
+	return b, err == nil
+}
+
+// numberLit returns the text of numeric literal with optional sign.
+func numberLit(expr ast.Expr) (string, bool) {
+	sign := ""
+
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.SUB {
+		sign, expr = "-", unary.X
+	}
+
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || (lit.Kind != token.INT && lit.Kind != token.FLOAT) {
+		return "", false
+	}
+
+	return sign + lit.Value, true
+}
+
+// conversionArg returns the number converted to type, e.g. 10 of int32(10).
+func conversionArg(expr ast.Expr) (string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return "", false
+	}
+
+	return numberLit(call.Args[0])
+}
+
+// trimSuffix returns ss without the trailing suffix elements.
+func trimSuffix(ss, suffix []string) []string {
+	if len(suffix) == 0 || len(suffix) > len(ss) || !reflect.DeepEqual(ss[len(ss)-len(suffix):], suffix) {
+		return ss
+	}
+
+	return ss[: len(ss)-len(suffix) : len(ss)-len(suffix)]
+}
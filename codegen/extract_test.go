@@ -0,0 +1,78 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExtract_Run extracts sources of cli/v2 golden files and checks that
+// code generated from extracted sources is the same as the golden one.
+func TestExtract_Run(t *testing.T) {
+	goldens, err := filepath.Glob(filepath.Join("testdata", "golden", "*", "cli_v2.go.golden"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, goldens)
+
+	for _, golden := range goldens {
+		t.Run(filepath.Base(filepath.Dir(golden)), func(t *testing.T) {
+			dir := t.TempDir()
+			extract := &Extract{
+				GoFile:     golden,
+				SourceFile: filepath.Join(dir, "config.yaml"),
+			}
+
+			if !assert.NoError(t, extract.Run()) {
+				return
+			}
+
+			gen := &Codegen{
+				SourceFile:  extract.SourceFile,
+				TargetPath:  filepath.Join(dir, "config.go"),
+				PackageName: "config",
+			}
+
+			if !assert.NoError(t, gen.Run()) {
+				return
+			}
+
+			expected, err := os.ReadFile(golden)
+			assert.NoError(t, err)
+
+			assertSameCode(t, expected, formatFile(t, gen.TargetPath))
+		})
+	}
+}
+
+func TestExtract_Run_unsupported(t *testing.T) {
+	extract := &Extract{
+		GoFile:     filepath.Join("testdata", "golden", "basic", "cobra.go.golden"),
+		SourceFile: filepath.Join(t.TempDir(), "config.yaml"),
+	}
+
+	assert.ErrorContains(t, extract.Run(), "only the built-in cli/v2 template is supported")
+}
+
+// assertSameCode compares generated code skipping the source comment.
+func assertSameCode(t *testing.T, want, got []byte) {
+	t.Helper()
+
+	sourceRe := regexp.MustCompile(`(?m)^// source: .*$`)
+	wantLines := strings.Split(sourceRe.ReplaceAllString(string(want), ""), "\n")
+	gotLines := strings.Split(sourceRe.ReplaceAllString(string(got), ""), "\n")
+
+	if line, ok := diffLine(strings.Join(wantLines, "\n"), strings.Join(gotLines, "\n")); !ok {
+		t.Errorf("generated code differs at line %d:\nwant: %s\ngot:  %s", line, lineAt(wantLines, line), lineAt(gotLines, line))
+	}
+}
+
+func lineAt(lines []string, n int) string {
+	if n > len(lines) {
+		return "<EOF>"
+	}
+
+	return lines[n-1]
+}
@@ -0,0 +1,1658 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"math"
+	"net/netip"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	config "github.com/partyzanex/cli-config-gen"
+	"github.com/pkg/errors"
+)
+
+type Flag struct {
+	Name     string      `yaml:"flag"`
+	Type     FlagType    `yaml:"type"`
+	Enum     []string    `yaml:"enum"`
+	Desc     string      `yaml:"desc"`
+	Required bool        `yaml:"required"`
+	Secret   bool        `yaml:"secret"`
+	Aliases  []string    `yaml:"aliases"`
+	Env      interface{} `yaml:"env"`
+	Value    interface{} `yaml:"value"`
+	// EnvExact contains environment variables which are used as is, e.g. legacy DATABASE_URL.
+	EnvExact []string `yaml:"envExact"`
+	// EnvPrimary disables the automatic <PREFIX><FLAG_NAME> environment variable if it's false.
+	EnvPrimary *bool `yaml:"envPrimary"`
+	// Values contains per-env values of map flags, Value of map flag is used for other environments.
+	Values map[string]interface{} `yaml:"values"`
+	// CustomType is the import path qualified type of custom flag, e.g. github.com/acme/log.Level,
+	// pointer to the type must implement encoding.TextUnmarshaler.
+	CustomType string `yaml:"goType"`
+	// Layout of timestamp flag, Go layout or name of time package constant, e.g. DateOnly, RFC3339 by default.
+	Layout string `yaml:"layout"`
+	// Timezone of timestamp flag values without offset, e.g. Local or Europe/Berlin, UTC by default.
+	Timezone string `yaml:"timezone"`
+	// Deprecated is the deprecation message of the flag, e.g. "use --new-name".
+	Deprecated string `yaml:"deprecated"`
+	// RenamedFrom contains old names of the flag which are still accepted with a warning.
+	RenamedFrom []string `yaml:"renamedFrom"`
+	// RemoveAfter is the date (YYYY-MM-DD) after which deprecated flag or old names will be removed.
+	RemoveAfter string `yaml:"removeAfter"`
+	// Hidden flag is not shown in help.
+	Hidden bool `yaml:"hidden"`
+	// DefaultText replaces the default value in help.
+	DefaultText string `yaml:"defaultText"`
+	// TakesFile marks string flags which take a file path, it's used by shell completion.
+	TakesFile bool `yaml:"takesFile"`
+	// GoName overrides the Go name of the flag used in identifiers of generated code, e.g. Port for PortFlagName.
+	GoName string `yaml:"goName"`
+
+	// customImport is the import of CustomType package resolved by Flags.
+	customImport *Import
+}
+
+func (flag *Flag) Args(env string) string {
+	switch flag.Type {
+	case FlagTypeString:
+		return flag.stringArg(env)
+	case FlagTypeTimestamp:
+		return flag.timestampArg(env)
+	case FlagTypeInt, FlagTypeInt32, FlagTypeInt64, FlagTypeUInt, FlagTypeUInt32, FlagTypeUInt64:
+		return flag.intArg(env)
+	case FlagTypeEnum:
+		return flag.enumArg(env)
+	case FlagTypeFloat32, FlagTypeFloat64:
+		return flag.floatArg(env)
+	case FlagTypeBytes:
+		return flag.bytesArg(env)
+	case FlagTypeBool:
+		return flag.boolArg(env)
+	case FlagTypeDuration:
+		return flag.durationArg(env)
+	case FlagTypeURL:
+		return flag.urlArg(env)
+	case FlagTypeHostPort:
+		return flag.hostPortArg(env)
+	case FlagTypeIP:
+		return flag.ipArg(env)
+	case FlagTypeIPSlice, FlagTypeCIDRSlice:
+		return flag.netSliceArg(env)
+	case FlagTypeStringMap, FlagTypeIntMap, FlagTypeInt64Map, FlagTypeFloat64Map:
+		return flag.mapArg(env)
+	case FlagTypeCustom:
+		return flag.customArg(env)
+	case FlagTypeFloat64Slice,
+		FlagTypeStringSlice,
+		FlagTypeIntSlice,
+		FlagTypeInt64Slice,
+		FlagTypeUIntSlice,
+		FlagTypeUInt64Slice,
+		FlagTypeBoolSlice,
+		FlagTypeDurationSlice:
+		return flag.sliceArg(env)
+	case FlagTypeEnumSlice:
+		return flag.enumSliceArg(env)
+	default:
+		panic(flag.errorf("Args: unsupported flag type"))
+	}
+}
+
+const (
+	enumMethodSet              = "Set"
+	enumMethodSetDuration      = "SetDuration"
+	enumMethodSetTime          = "SetTime"
+	enumMethodSetStringSlice   = "SetStringSlice"
+	enumMethodSetIntSlice      = "SetIntSlice"
+	enumMethodSetInt64Slice    = "SetInt64Slice"
+	enumMethodSetUIntSlice     = "SetUIntSlice"
+	enumMethodSetUInt64Slice   = "SetUInt64Slice"
+	enumMethodSetFloat64Slice  = "SetFloat64Slice"
+	enumMethodSetBoolSlice     = "SetBoolSlice"
+	enumMethodSetDurationSlice = "SetDurationSlice"
+	enumMethodSetIPSlice       = "SetIPSlice"
+	enumMethodSetCIDRSlice     = "SetCIDRSlice"
+	enumMethodSetStringMap     = "SetStringMap"
+	enumMethodSetIntMap        = "SetIntMap"
+	enumMethodSetInt64Map      = "SetInt64Map"
+	enumMethodSetFloat64Map    = "SetFloat64Map"
+)
+
+func (flag *Flag) ValueSetMethodName() string {
+	switch flag.Type {
+	case FlagTypeString,
+		FlagTypeBool,
+		FlagTypeEnum,
+		FlagTypeInt,
+		FlagTypeInt32,
+		FlagTypeInt64,
+		FlagTypeUInt,
+		FlagTypeUInt32,
+		FlagTypeUInt64,
+		FlagTypeFloat32,
+		FlagTypeFloat64,
+		FlagTypeBytes,
+		FlagTypeURL,
+		FlagTypeHostPort,
+		FlagTypeIP,
+		FlagTypeCustom,
+		FlagTypeEnumSlice:
+		return enumMethodSet
+	case FlagTypeDuration:
+		return enumMethodSetDuration
+	case FlagTypeTimestamp:
+		return enumMethodSetTime
+	case FlagTypeStringSlice:
+		return enumMethodSetStringSlice
+	case FlagTypeIntSlice:
+		return enumMethodSetIntSlice
+	case FlagTypeInt64Slice:
+		return enumMethodSetInt64Slice
+	case FlagTypeUIntSlice:
+		return enumMethodSetUIntSlice
+	case FlagTypeUInt64Slice:
+		return enumMethodSetUInt64Slice
+	case FlagTypeFloat64Slice:
+		return enumMethodSetFloat64Slice
+	case FlagTypeBoolSlice:
+		return enumMethodSetBoolSlice
+	case FlagTypeDurationSlice:
+		return enumMethodSetDurationSlice
+	case FlagTypeIPSlice:
+		return enumMethodSetIPSlice
+	case FlagTypeCIDRSlice:
+		return enumMethodSetCIDRSlice
+	case FlagTypeStringMap:
+		return enumMethodSetStringMap
+	case FlagTypeIntMap:
+		return enumMethodSetIntMap
+	case FlagTypeInt64Map:
+		return enumMethodSetInt64Map
+	case FlagTypeFloat64Map:
+		return enumMethodSetFloat64Map
+	default:
+		panic(flag.errorf("ValueSetMethodName: unknown flag type %q", flag.Type))
+	}
+}
+
+const (
+	enumValueTypeString        = "String"
+	enumValueTypeInt           = "Int"
+	enumValueTypeInt32         = "Int32"
+	enumValueTypeInt64         = "Int64"
+	enumValueTypeUint          = "Uint"
+	enumValueTypeUint32        = "Uint32"
+	enumValueTypeUint64        = "Uint64"
+	enumValueTypeFloat32       = "Float32"
+	enumValueTypeFloat64       = "Float64"
+	enumValueTypeBytes         = "Bytes"
+	enumValueTypeBool          = "Bool"
+	enumValueTypeTimestamp     = "Timestamp"
+	enumValueTypeDuration      = "Duration"
+	enumValueTypeStringSlice   = "StringSlice"
+	enumValueTypeIntSlice      = "IntSlice"
+	enumValueTypeInt64Slice    = "Int64Slice"
+	enumValueTypeUintSlice     = "UintSlice"
+	enumValueTypeUint64Slice   = "Uint64Slice"
+	enumValueTypeFloat64Slice  = "Float64Slice"
+	enumValueTypeBoolSlice     = "BoolSlice"
+	enumValueTypeDurationSlice = "DurationSlice"
+	enumValueTypeEnumSlice     = "EnumSlice"
+	enumValueTypeURL           = "URL"
+	enumValueTypeHostPort      = "HostPort"
+	enumValueTypeIP            = "IP"
+	enumValueTypeIPSlice       = "IPSlice"
+	enumValueTypeCIDRSlice     = "CIDRSlice"
+	enumValueTypeStringMap     = "StringMap"
+	enumValueTypeIntMap        = "IntMap"
+	enumValueTypeInt64Map      = "Int64Map"
+	enumValueTypeFloat64Map    = "Float64Map"
+)
+
+func (flag *Flag) ValueType() string {
+	switch flag.Type {
+	case FlagTypeString, FlagTypeEnum:
+		return enumValueTypeString
+	case FlagTypeInt:
+		return enumValueTypeInt
+	case FlagTypeInt32:
+		return enumValueTypeInt32
+	case FlagTypeInt64:
+		return enumValueTypeInt64
+	case FlagTypeUInt:
+		return enumValueTypeUint
+	case FlagTypeUInt32:
+		return enumValueTypeUint32
+	case FlagTypeUInt64:
+		return enumValueTypeUint64
+	case FlagTypeFloat32:
+		return enumValueTypeFloat32
+	case FlagTypeFloat64:
+		return enumValueTypeFloat64
+	case FlagTypeBytes:
+		return enumValueTypeBytes
+	case FlagTypeBool:
+		return enumValueTypeBool
+	case FlagTypeTimestamp:
+		return enumValueTypeTimestamp
+	case FlagTypeDuration:
+		return enumValueTypeDuration
+	case FlagTypeStringSlice:
+		return enumValueTypeStringSlice
+	case FlagTypeIntSlice:
+		return enumValueTypeIntSlice
+	case FlagTypeInt64Slice:
+		return enumValueTypeInt64Slice
+	case FlagTypeUIntSlice:
+		return enumValueTypeUintSlice
+	case FlagTypeUInt64Slice:
+		return enumValueTypeUint64Slice
+	case FlagTypeFloat64Slice:
+		return enumValueTypeFloat64Slice
+	case FlagTypeBoolSlice:
+		return enumValueTypeBoolSlice
+	case FlagTypeDurationSlice:
+		return enumValueTypeDurationSlice
+	case FlagTypeEnumSlice:
+		return enumValueTypeEnumSlice
+	case FlagTypeURL:
+		return enumValueTypeURL
+	case FlagTypeHostPort:
+		return enumValueTypeHostPort
+	case FlagTypeIP:
+		return enumValueTypeIP
+	case FlagTypeIPSlice:
+		return enumValueTypeIPSlice
+	case FlagTypeCIDRSlice:
+		return enumValueTypeCIDRSlice
+	case FlagTypeStringMap:
+		return enumValueTypeStringMap
+	case FlagTypeIntMap:
+		return enumValueTypeIntMap
+	case FlagTypeInt64Map:
+		return enumValueTypeInt64Map
+	case FlagTypeFloat64Map:
+		return enumValueTypeFloat64Map
+	default:
+		return ""
+	}
+}
+
+const enumPFlagTypeTime = "Time"
+
+// PFlagType returns the name of pflag.FlagSet method family for the flag, e.g. StringSlice for StringSliceP.
+func (flag *Flag) PFlagType() string {
+	if flag.Type == FlagTypeTimestamp {
+		return enumPFlagTypeTime
+	}
+
+	return flag.ValueType()
+}
+
+// PFlagShorthand returns the first single letter alias of the flag.
+func (flag *Flag) PFlagShorthand() string {
+	for _, alias := range flag.Aliases {
+		if len(alias) == 1 {
+			return alias
+		}
+	}
+
+	return ""
+}
+
+func (flag *Flag) IsSlice() bool {
+	switch flag.Type {
+	case FlagTypeStringSlice,
+		FlagTypeIntSlice,
+		FlagTypeInt64Slice,
+		FlagTypeUIntSlice,
+		FlagTypeUInt64Slice,
+		FlagTypeFloat64Slice,
+		FlagTypeBoolSlice,
+		FlagTypeDurationSlice,
+		FlagTypeEnumSlice,
+		FlagTypeIPSlice,
+		FlagTypeCIDRSlice:
+		return true
+	default:
+		return false
+	}
+}
+
+// HasAccessor reports whether Go type of the flag value is declared by generated package
+// or imported by it, so generated package declares typed accessor of the value.
+func (flag *Flag) HasAccessor() bool {
+	return flag.Type == FlagTypeCustom || flag.Type == FlagTypeEnumSlice
+}
+
+// GoIdent returns the Go name of the flag used in identifiers of generated code,
+// it's GoName or camel case of the flag name.
+func (flag *Flag) GoIdent() string {
+	if flag.GoName != "" {
+		return flag.GoName
+	}
+
+	return strcase.ToCamel(flag.Name)
+}
+
+// EnumType returns the name of element type of enum slice flag.
+func (flag *Flag) EnumType() string {
+	return flag.GoIdent() + "Enum"
+}
+
+func (flag *Flag) IsMap() bool {
+	switch flag.Type {
+	case FlagTypeStringMap, FlagTypeIntMap, FlagTypeInt64Map, FlagTypeFloat64Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsNet reports whether the flag value is network address of net/netip package.
+func (flag *Flag) IsNet() bool {
+	switch flag.Type {
+	case FlagTypeIP, FlagTypeIPSlice, FlagTypeCIDRSlice:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsGeneric reports whether the target lib has no native flag for the type,
+// so the flag is generated as generic one with Value implementation of the runtime package.
+func (flag *Flag) IsGeneric(lib string) bool {
+	switch flag.Type {
+	case FlagTypeBytes,
+		FlagTypeBoolSlice,
+		FlagTypeDurationSlice,
+		FlagTypeEnumSlice,
+		FlagTypeURL,
+		FlagTypeHostPort,
+		FlagTypeIP,
+		FlagTypeIPSlice,
+		FlagTypeCIDRSlice,
+		FlagTypeStringMap,
+		FlagTypeIntMap,
+		FlagTypeInt64Map,
+		FlagTypeFloat64Map,
+		FlagTypeCustom:
+		return true
+	case FlagTypeInt32, FlagTypeUInt32, FlagTypeFloat32:
+		return lib == TargetLibCLIv2 || lib == TargetLibFlag
+	case FlagTypeUIntSlice, FlagTypeUInt64Slice:
+		// actions of uint slice flags of urfave/cli v2 aren't run
+		return lib == TargetLibCLIv2
+	case FlagTypeTimestamp:
+		// pflag parses time in UTC only
+		return lib == TargetLibCobra && flag.Timezone != "" && flag.Timezone != "UTC"
+	default:
+		return false
+	}
+}
+
+// ArgsOverrideEnv reports whether the generic flag of lib accumulates parsed values,
+// so values parsed from environment variables are reset by args, see config.ArgsOverrideEnv.
+func (flag *Flag) ArgsOverrideEnv(lib string) bool {
+	return lib == TargetLibCLIv2 && flag.IsGeneric(lib) && (flag.IsSlice() || flag.IsMap())
+}
+
+// RequiredField returns Required of urfave/cli flag, required renamed flags are checked by RequiredRenamed
+// because cli checks required flags only by their names.
+func (flag *Flag) RequiredField() string {
+	return strconv.FormatBool(flag.Required && len(flag.RenamedFrom) == 0)
+}
+
+func (flag *Flag) SecretField() string {
+	return strconv.FormatBool(flag.Secret)
+}
+
+// redactedText replaces default values of secret flags in help, it's the redacted value of PrintConfig.
+const redactedText = "******"
+
+// HelpDefaultText returns the text shown in help instead of the default value,
+// defaults of secret flags are redacted unless DefaultText is set.
+func (flag *Flag) HelpDefaultText() string {
+	if flag.DefaultText == "" && flag.Secret && (flag.Value != nil || flag.Values != nil) {
+		return redactedText
+	}
+
+	return flag.DefaultText
+}
+
+// EnvVarsField returns environment variables of the flag, prefix is the prefix of automatic names, see App.EnvVarPrefix.
+func (flag *Flag) EnvVarsField(prefix string) string {
+	names := flag.EnvVars(prefix)
+	if len(names) == 0 {
+		return nilStr
+	}
+
+	for i, name := range names {
+		names[i] = strconv.Quote(name)
+	}
+
+	return fmt.Sprintf("[]string{%s}", strings.Join(names, ", "))
+}
+
+// EnvVars returns environment variables of the flag, environment variables of old names of required flag
+// are included to set the flag before cli checks required flags.
+func (flag *Flag) EnvVars(prefix string) []string {
+	var names []string
+
+	if flag.hasPrimaryEnvVar() {
+		names = append(names, prefix+strcase.ToScreamingSnake(flag.Name))
+	}
+
+	switch env := flag.Env.(type) {
+	case []interface{}:
+		for _, e := range env {
+			s, ok := e.(string)
+			if !ok {
+				panic(flag.errorf("EnvVars: unsupported env value type %T", e))
+			}
+
+			names = append(names, strcase.ToScreamingSnake(s))
+		}
+	case string:
+		names = append(names, strcase.ToScreamingSnake(env))
+	case bool:
+		return nil
+	case nil:
+		// nothing
+	default:
+		panic(flag.errorf("EnvVars: unknown env type %T", flag.Env))
+	}
+
+	names = append(names, flag.EnvExact...)
+
+	if flag.Required {
+		names = append(names, flag.renamedEnvVars(prefix)...)
+	}
+
+	return names
+}
+
+// EnvVarsWithRenamedField returns environment variables of the flag followed by environment variables of old names.
+func (flag *Flag) EnvVarsWithRenamedField(prefix string) string {
+	field := flag.EnvVarsField(prefix)
+
+	renamed := flag.renamedEnvVars(prefix)
+	if flag.Required || field == nilStr || len(renamed) == 0 {
+		return field
+	}
+
+	return strings.TrimSuffix(field, "}") + `, "` + strings.Join(renamed, `", "`) + `"}`
+}
+
+// RenamedEnvVar returns environment variable of the old flag name,
+// it's empty if automatic environment variables are disabled.
+func (flag *Flag) RenamedEnvVar(prefix, name string) string {
+	if !flag.hasPrimaryEnvVar() {
+		return ""
+	}
+
+	return prefix + strcase.ToScreamingSnake(name)
+}
+
+// RenamedEnvVarsField returns environment variables of old flag names,
+// they are accepted after the environment variables of the flag.
+func (flag *Flag) RenamedEnvVarsField(prefix string) string {
+	names := flag.renamedEnvVars(prefix)
+	if len(names) == 0 {
+		return nilStr
+	}
+
+	return stringSlice(names)
+}
+
+func (flag *Flag) renamedEnvVars(prefix string) []string {
+	if !flag.hasPrimaryEnvVar() {
+		return nil
+	}
+
+	names := make([]string, len(flag.RenamedFrom))
+
+	for i, name := range flag.RenamedFrom {
+		names[i] = flag.RenamedEnvVar(prefix, name)
+	}
+
+	return names
+}
+
+// hasPrimaryEnvVar reports whether the automatic <PREFIX><FLAG_NAME> environment variable is used.
+func (flag *Flag) hasPrimaryEnvVar() bool {
+	if _, disabled := flag.Env.(bool); disabled {
+		return false
+	}
+
+	return flag.EnvPrimary == nil || *flag.EnvPrimary
+}
+
+const (
+	enumGoTypeString        = "string"
+	enumGoTypeInt           = "int"
+	enumGoTypeInt32         = "int32"
+	enumGoTypeInt64         = "int64"
+	enumGoTypeUint          = "uint"
+	enumGoTypeUint32        = "uint32"
+	enumGoTypeUint64        = "uint64"
+	enumGoTypeFloat32       = "float32"
+	enumGoTypeFloat64       = "float64"
+	enumGoTypeBool          = "bool"
+	enumGoTypeDuration      = "time.Duration"
+	enumGoTypeTime          = "time.Time"
+	enumGoTypeStringSlice   = "[]string"
+	enumGoTypeIntSlice      = "[]int"
+	enumGoTypeInt64Slice    = "[]int64"
+	enumGoTypeUintSlice     = "[]uint"
+	enumGoTypeUint64Slice   = "[]uint64"
+	enumGoTypeFloat64Slice  = "[]float64"
+	enumGoTypeBoolSlice     = "[]bool"
+	enumGoTypeDurationSlice = "[]time.Duration"
+	enumGoTypeURL           = "*url.URL"
+	enumGoTypeIP            = "netip.Addr"
+	enumGoTypeIPSlice       = "[]netip.Addr"
+	enumGoTypeCIDRSlice     = "[]netip.Prefix"
+	enumGoTypeStringMap     = "map[string]string"
+	enumGoTypeIntMap        = "map[string]int"
+	enumGoTypeInt64Map      = "map[string]int64"
+	enumGoTypeFloat64Map    = "map[string]float64"
+)
+
+func (flag *Flag) GoType() string {
+	switch flag.Type {
+	case FlagTypeString, FlagTypeEnum, FlagTypeHostPort:
+		return enumGoTypeString
+	case FlagTypeInt:
+		return enumGoTypeInt
+	case FlagTypeInt32:
+		return enumGoTypeInt32
+	case FlagTypeInt64:
+		return enumGoTypeInt64
+	case FlagTypeUInt:
+		return enumGoTypeUint
+	case FlagTypeUInt32:
+		return enumGoTypeUint32
+	case FlagTypeUInt64, FlagTypeBytes:
+		return enumGoTypeUint64
+	case FlagTypeFloat32:
+		return enumGoTypeFloat32
+	case FlagTypeFloat64:
+		return enumGoTypeFloat64
+	case FlagTypeBool:
+		return enumGoTypeBool
+	case FlagTypeDuration:
+		return enumGoTypeDuration
+	case FlagTypeTimestamp:
+		return enumGoTypeTime
+	case FlagTypeStringSlice:
+		return enumGoTypeStringSlice
+	case FlagTypeIntSlice:
+		return enumGoTypeIntSlice
+	case FlagTypeInt64Slice:
+		return enumGoTypeInt64Slice
+	case FlagTypeUIntSlice:
+		return enumGoTypeUintSlice
+	case FlagTypeUInt64Slice:
+		return enumGoTypeUint64Slice
+	case FlagTypeFloat64Slice:
+		return enumGoTypeFloat64Slice
+	case FlagTypeBoolSlice:
+		return enumGoTypeBoolSlice
+	case FlagTypeDurationSlice:
+		return enumGoTypeDurationSlice
+	case FlagTypeEnumSlice:
+		return "[]" + flag.EnumType()
+	case FlagTypeURL:
+		return enumGoTypeURL
+	case FlagTypeIP:
+		return enumGoTypeIP
+	case FlagTypeIPSlice:
+		return enumGoTypeIPSlice
+	case FlagTypeCIDRSlice:
+		return enumGoTypeCIDRSlice
+	case FlagTypeStringMap:
+		return enumGoTypeStringMap
+	case FlagTypeIntMap:
+		return enumGoTypeIntMap
+	case FlagTypeInt64Map:
+		return enumGoTypeInt64Map
+	case FlagTypeFloat64Map:
+		return enumGoTypeFloat64Map
+	case FlagTypeCustom:
+		return flag.customGoType()
+	default:
+		panic(flag.errorf("unsupported flag type %q", flag.Type))
+	}
+}
+
+// GenericValue returns Go expression of generic flag value initialized from Value variable.
+func (flag *Flag) GenericValue(variable string) string {
+	switch flag.Type {
+	case FlagTypeCustom:
+		return fmt.Sprintf("NewTextValue(ValueOf[%s](%s))", flag.GoType(), variable)
+	case FlagTypeEnumSlice:
+		return fmt.Sprintf("NewEnumSliceValue(ValueOf[%s](%s), %s)", flag.GoType(), variable, flag.enumConsts())
+	case FlagTypeTimestamp:
+		return fmt.Sprintf("NewTimeValue(%s.TimestampValue(), %s, %s)", variable, flag.LayoutExpr(), flag.LocationExpr())
+	case FlagTypeUIntSlice, FlagTypeUInt64Slice:
+		return fmt.Sprintf("New%sValue(%s.%s())", flag.ValueType(), variable, flag.Accessor())
+	}
+
+	return fmt.Sprintf("New%sValue(%s.%s())", flag.ValueType(), variable, flag.ValueType())
+}
+
+// ElemGoType returns Go type of slice or map element.
+func (flag *Flag) ElemGoType() string {
+	if flag.IsMap() {
+		return strings.TrimPrefix(flag.GoType(), "map[string]")
+	}
+
+	return strings.TrimPrefix(flag.GoType(), "[]")
+}
+
+// ElemValueType returns ValueType of slice or map element, e.g. Int for IntSlice or IntMap.
+func (flag *Flag) ElemValueType() string {
+	if flag.IsMap() {
+		return strings.TrimSuffix(flag.ValueType(), "Map")
+	}
+
+	return strings.TrimSuffix(flag.ValueType(), "Slice")
+}
+
+// StdFlagType returns the name of flag.FlagSet method family for the flag, e.g. Int for IntVar,
+// or empty string if the standard library has no flag for the type.
+func (flag *Flag) StdFlagType() string {
+	switch flag.Type {
+	case FlagTypeString,
+		FlagTypeBool,
+		FlagTypeInt,
+		FlagTypeInt64,
+		FlagTypeUInt,
+		FlagTypeUInt64,
+		FlagTypeFloat64,
+		FlagTypeDuration:
+		return flag.ValueType()
+	default:
+		return ""
+	}
+}
+
+// GoLiteral returns Go expression of the flag value for env.
+func (flag *Flag) GoLiteral(env string) string {
+	switch {
+	case flag.Type == FlagTypeEnumSlice:
+		return flag.Args(env)
+	case flag.IsSlice():
+		return fmt.Sprintf("%s{%s}", flag.GoType(), flag.Args(env))
+	case flag.Type == FlagTypeTimestamp:
+		return flag.timeLiteral(env, flag.GoLocation())
+	case flag.Type == FlagTypeEnum && flag.Value == nil:
+		return `""`
+	case flag.Type == FlagTypeURL:
+		// generated package of flag target lib has own helper
+		return flag.urlLiteral(env, "mustParseURL")
+	case flag.Type == FlagTypeCustom:
+		text, ok := flag.customText(env)
+		if !ok {
+			return fmt.Sprintf("*new(%s)", flag.GoType())
+		}
+
+		return fmt.Sprintf("mustParseText[%s](%q)", flag.GoType(), text)
+	default:
+		return flag.Args(env)
+	}
+}
+
+const nilStr = "nil"
+
+// AliasesField returns aliases of urfave/cli flag.
+func (flag *Flag) AliasesField() string {
+	if len(flag.Aliases) == 0 {
+		return nilStr
+	}
+
+	return stringSlice(flag.Aliases)
+}
+
+// stringSlice returns Go literal of the string slice.
+func stringSlice(ss []string) string {
+	elems := make([]string, len(ss))
+	for i, s := range ss {
+		elems[i] = strconv.Quote(s)
+	}
+
+	return "[]string{" + strings.Join(elems, ", ") + "}"
+}
+
+// HiddenRenames returns old names of urfave/cli flag which are defined as hidden flags.
+func (flag *Flag) HiddenRenames() []string {
+	return flag.RenamedFrom
+}
+
+func (flag *Flag) IsDeprecated() bool {
+	return flag.Deprecated != ""
+}
+
+func (flag *Flag) DescField() string {
+	desc := flag.Desc
+
+	if flag.Type == FlagTypeEnum || flag.Type == FlagTypeEnumSlice {
+		if desc != "" {
+			desc = fmt.Sprintf("%s, (variants: %s)", desc, strings.Join(flag.Enum, ", "))
+		} else {
+			desc = fmt.Sprintf("variants: %s", strings.Join(flag.Enum, ", "))
+		}
+	}
+
+	if flag.IsDeprecated() {
+		deprecated := "DEPRECATED: " + flag.Deprecated
+		if flag.RemoveAfter != "" {
+			deprecated += ", will be removed after " + flag.RemoveAfter
+		}
+
+		desc = strings.TrimSpace(desc + " (" + deprecated + ")")
+	}
+
+	return desc
+}
+
+// DeprecationDoc returns the doc comment paragraph about deprecation or old names of the flag.
+func (flag *Flag) DeprecationDoc() string {
+	var removal string
+	if flag.RemoveAfter != "" {
+		removal = ", it will be removed after " + flag.RemoveAfter
+	}
+
+	switch {
+	case flag.IsDeprecated():
+		// the message is written in a single line comment
+		msg := strings.Join(strings.Fields(flag.Deprecated), " ")
+
+		return "Deprecated: " + strings.TrimSuffix(msg, ".") + removal + "."
+	case len(flag.RenamedFrom) > 0:
+		if removal != "" {
+			removal = ", old names will be removed after " + flag.RemoveAfter
+		}
+
+		return "Renamed from --" + strings.Join(flag.RenamedFrom, ", --") + removal + "."
+	default:
+		return ""
+	}
+}
+
+// DeprecationWarnings returns statements which print warnings when deprecated flag or its old names are used,
+// variable is the name of flag name constant, setByArgs is Go expression of the func which reports
+// whether the flag with given name was set by args, see WarnRenamed.
+func (flag *Flag) DeprecationWarnings(variable, envPrefix, setByArgs string) string {
+	var b strings.Builder
+
+	if len(flag.RenamedFrom) > 0 {
+		fmt.Fprintf(&b, "WarnRenamed(%s, %s, %s, %s, %q)\n",
+			variable, stringSlice(flag.RenamedFrom), setByArgs, flag.RenamedEnvVarsField(envPrefix), flag.RemoveAfter,
+		)
+	}
+
+	if flag.IsDeprecated() {
+		fmt.Fprintf(&b, "WarnDeprecated(%s, %q, %q)\n", variable, flag.Deprecated, flag.RemoveAfter)
+	}
+
+	return b.String()
+}
+
+func (flag *Flag) validate() error {
+	if !isGoName(flag.Name) {
+		return errors.Errorf("invalid flag name %q, expected letters, digits, dashes, dots and underscores starting with a letter", flag.Name)
+	}
+
+	if flag.GoName != "" && (!token.IsIdentifier(flag.GoName) || !token.IsExported(flag.GoName)) {
+		return errors.Errorf("invalid goName %q of flag %q, expected exported Go identifier", flag.GoName, flag.Name)
+	}
+
+	if err := flag.validateEnum(); err != nil {
+		return err
+	}
+
+	if flag.TakesFile && flag.Type != FlagTypeString && flag.Type != FlagTypeStringSlice {
+		return errors.Errorf("takesFile of flag %q is supported only by string and stringSlice flags", flag.Name)
+	}
+
+	return flag.validateDeprecation()
+}
+
+// validateEnum checks that enum variants are converted to unique Go constants.
+func (flag *Flag) validateEnum() error {
+	consts := make(map[string]string, len(flag.Enum))
+
+	for _, variant := range flag.Enum {
+		name := flag.GoIdent() + strcase.ToCamel(variant)
+		if variant == "" || !token.IsIdentifier(name) {
+			return errors.Errorf("invalid enum variant %q of flag %q", variant, flag.Name)
+		}
+
+		if other, ok := consts[name]; ok {
+			return errors.Errorf("enum variants %q and %q of flag %q have the same constant %s", other, variant, flag.Name, name)
+		}
+
+		consts[name] = variant
+	}
+
+	return nil
+}
+
+func (flag *Flag) validateDeprecation() error {
+	for _, name := range flag.RenamedFrom {
+		if !nameRe.MatchString(name) {
+			return errors.Errorf("invalid old name %q of flag %q", name, flag.Name)
+		}
+	}
+
+	if flag.RemoveAfter == "" {
+		return nil
+	}
+
+	if !flag.IsDeprecated() && len(flag.RenamedFrom) == 0 {
+		return errors.Errorf("removeAfter of flag %q requires deprecated or renamedFrom", flag.Name)
+	}
+
+	if _, err := time.Parse(time.DateOnly, flag.RemoveAfter); err != nil {
+		return errors.Errorf("invalid removeAfter %q of flag %q, expected YYYY-MM-DD", flag.RemoveAfter, flag.Name)
+	}
+
+	return nil
+}
+
+func (flag *Flag) sliceArg(env string) string {
+	var ss []interface{}
+
+	switch v := flag.Value.(type) {
+	case map[string]interface{}:
+		var ok bool
+
+		ss, ok = v[env].([]interface{})
+		if !ok {
+			return ``
+		}
+	case []interface{}:
+		ss = v
+	case nil:
+		// nothing
+	default:
+		panic(flag.errorf("sliceArg: unsupported type %T", flag.Value))
+	}
+
+	r := make([]string, len(ss))
+
+	for i, s := range ss {
+		n, ok := scalarString(s)
+		if !ok {
+			panic(flag.errorf("sliceArg: unsupported value type %T", s))
+		}
+
+		switch flag.Type {
+		case FlagTypeStringSlice:
+			n = strconv.Quote(n)
+		case FlagTypeIntSlice, FlagTypeInt64Slice:
+			if _, err := strconv.ParseInt(n, 10, 64); err != nil {
+				panic(flag.errorf("sliceArg: invalid integer %q", n))
+			}
+		case FlagTypeUIntSlice, FlagTypeUInt64Slice:
+			if _, err := strconv.ParseUint(n, 10, 64); err != nil {
+				panic(flag.errorf("sliceArg: invalid unsigned integer %q", n))
+			}
+		case FlagTypeFloat64Slice:
+			if !isFiniteFloat(n) {
+				panic(flag.errorf("sliceArg: invalid float %q", n))
+			}
+		case FlagTypeBoolSlice:
+			if _, err := strconv.ParseBool(n); err != nil {
+				panic(flag.errorf("sliceArg: %s", err))
+			}
+		case FlagTypeDurationSlice:
+			d, err := time.ParseDuration(n)
+			if err != nil {
+				panic(flag.errorf("sliceArg: %s", err))
+			}
+
+			n = fmt.Sprintf("time.Duration(%d)", d)
+		}
+
+		r[i] = n
+	}
+
+	return strings.Join(r, ",")
+}
+
+func (flag *Flag) enumConsts() string {
+	consts := make([]string, len(flag.Enum))
+
+	for i, variant := range flag.Enum {
+		consts[i] = flag.GoIdent() + strcase.ToCamel(variant)
+	}
+
+	return strings.Join(consts, ", ")
+}
+
+func (flag *Flag) enumSliceArg(env string) string {
+	var values []interface{}
+
+	switch v := flag.envValue(env).(type) {
+	case []interface{}:
+		values = v
+	case string:
+		values = []interface{}{v}
+	case nil:
+		// nothing
+	default:
+		panic(flag.errorf("enumSliceArg: unsupported type %T", v))
+	}
+
+	consts := make([]string, len(values))
+
+	for i, value := range values {
+		s, ok := value.(string)
+		if !ok || !flag.isEnumVariant(s) {
+			panic(flag.errorf("enumSliceArg: value %v is not one of %s", value, strings.Join(flag.Enum, ", ")))
+		}
+
+		consts[i] = flag.GoIdent() + strcase.ToCamel(s)
+	}
+
+	return fmt.Sprintf("%s{%s}", flag.GoType(), strings.Join(consts, ", "))
+}
+
+func (flag *Flag) isEnumVariant(s string) bool {
+	for _, variant := range flag.Enum {
+		if s == variant {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (flag *Flag) durationArg(env string) string {
+	var d int64
+
+	switch v := flag.Value.(type) {
+	case string:
+		dur, err := time.ParseDuration(v)
+		if err != nil {
+			panic(flag.errorf("durationArg: %s", err))
+		}
+
+		d = dur.Nanoseconds()
+	case map[string]interface{}:
+		str, ok := v[env].(string)
+		if ok {
+			dur, err := time.ParseDuration(str)
+			if err != nil {
+				panic(flag.errorf("durationArg: %s", err))
+			}
+
+			d = dur.Nanoseconds()
+		}
+	case nil:
+		// nothing
+	default:
+		panic(flag.errorf("durationArg: unsupported type %T", flag.Value))
+	}
+
+	return fmt.Sprintf("time.Duration(%d)", d)
+}
+
+func (flag *Flag) boolArg(env string) string {
+	var b bool
+
+	switch v := flag.Value.(type) {
+	case bool:
+		b = v
+	case map[string]interface{}:
+		a, ok := v[env].(bool)
+		if ok {
+			b = a
+		}
+	case nil:
+		// nothing
+	default:
+		panic(flag.errorf("boolArg: unsupported type %T", flag.Value))
+	}
+
+	return strconv.FormatBool(b)
+}
+
+func (flag *Flag) floatArg(env string) string {
+	var f float64
+
+	switch v := flag.envValue(env).(type) {
+	case float64:
+		f = v
+	case int:
+		f = float64(v)
+	case nil:
+		// nothing
+	default:
+		panic(flag.errorf("floatArg: unsupported type %T", v))
+	}
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic(flag.errorf("floatArg: value %v is not finite", f))
+	}
+
+	if flag.Type == FlagTypeFloat32 && math.Abs(f) > math.MaxFloat32 {
+		panic(flag.errorf("floatArg: value %v overflows %s", f, flag.GoType()))
+	}
+
+	return fmt.Sprintf("%s(%s)", flag.GoType(), strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+func (flag *Flag) enumArg(env string) string {
+	var value string
+
+	switch v := flag.Value.(type) {
+	case string:
+		value = v
+	case map[string]interface{}:
+		e, ok := v[env].(string)
+		if !ok {
+			panic(flag.errorf("undefined value for env %q", env))
+		}
+
+		value = e
+	case nil:
+		return ""
+	default:
+		panic(flag.errorf("unsupported type %T", flag.Value))
+	}
+
+	if !slices.Contains(flag.Enum, value) {
+		panic(flag.errorf("enumArg: value %q is not one of %s", value, strings.Join(flag.Enum, ", ")))
+	}
+
+	return flag.GoIdent() + strcase.ToCamel(value)
+}
+
+func toInt64(v interface{}) (int64, error) {
+	switch i := v.(type) {
+	case int:
+		return int64(i), nil
+	case int64:
+		return i, nil
+	default:
+		return 0, errors.Errorf("unsupported int type %T[%v]", v, v)
+	}
+}
+
+func (flag *Flag) intArg(env string) string {
+	var (
+		i   int64
+		err error
+	)
+
+	switch flag.Value.(type) {
+	case int, int64:
+		i, err = toInt64(flag.Value)
+		if err != nil {
+			panic(flag.errorf("toInt64: %s", err))
+		}
+	case map[string]interface{}:
+		m := flag.Value.(map[string]interface{})
+
+		v, ok := m[env]
+		if ok {
+			i, err = toInt64(v)
+			if err != nil {
+				panic(flag.errorf("toInt64: %s", err))
+			}
+		}
+	case nil:
+		// nothing
+	default:
+		panic(flag.errorf("intArg: unsupported type %T", flag.Value))
+	}
+
+	switch {
+	case flag.Type == FlagTypeInt32 && (i < math.MinInt32 || i > math.MaxInt32),
+		flag.Type == FlagTypeUInt32 && (i < 0 || i > math.MaxUint32):
+		panic(flag.errorf("intArg: value %d overflows %s", i, flag.GoType()))
+	}
+
+	return fmt.Sprintf("%s(%s)", flag.GoType(), strconv.FormatInt(i, 10))
+}
+
+func (flag *Flag) bytesArg(env string) string {
+	var (
+		size uint64
+		err  error
+	)
+
+	value := flag.Value
+	if m, ok := value.(map[string]interface{}); ok {
+		value = m[env]
+	}
+
+	switch v := value.(type) {
+	case string:
+		size, err = config.ParseBytes(v)
+		if err != nil {
+			panic(flag.errorf("bytesArg: %s", err))
+		}
+	case int:
+		if v < 0 {
+			panic(flag.errorf("bytesArg: negative size %d", v))
+		}
+
+		size = uint64(v)
+	case uint64:
+		size = v
+	case nil:
+		// nothing
+	default:
+		panic(flag.errorf("bytesArg: unsupported type %T", value))
+	}
+
+	return fmt.Sprintf("uint64(%d)", size)
+}
+
+func (flag *Flag) envValue(env string) interface{} {
+	if m, ok := flag.Value.(map[string]interface{}); ok {
+		return m[env]
+	}
+
+	return flag.Value
+}
+
+// defaultValue returns the YAML default value of the flag for env,
+// map flags use per-env values and Value for environments without them.
+func (flag *Flag) defaultValue(env string) interface{} {
+	if !flag.IsMap() {
+		return flag.envValue(env)
+	}
+
+	if value, ok := flag.Values[env]; ok {
+		return value
+	}
+
+	return flag.Value
+}
+
+func (flag *Flag) urlArg(env string) string {
+	return flag.urlLiteral(env, "MustParseURL")
+}
+
+// urlLiteral returns Go expression of the URL value for env parsed by the parse function.
+func (flag *Flag) urlLiteral(env, parse string) string {
+	switch v := flag.envValue(env).(type) {
+	case string:
+		if _, err := config.ParseURL(v); err != nil {
+			panic(flag.errorf("urlArg: %s", err))
+		}
+
+		return fmt.Sprintf("%s(%q)", parse, v)
+	case nil:
+		return nilStr
+	default:
+		panic(flag.errorf("urlArg: unsupported type %T", v))
+	}
+}
+
+func (flag *Flag) hostPortArg(env string) string {
+	switch v := flag.envValue(env).(type) {
+	case string:
+		if _, err := config.ParseHostPort(v); err != nil {
+			panic(flag.errorf("hostPortArg: %s", err))
+		}
+
+		return strconv.Quote(v)
+	case nil:
+		return `""`
+	default:
+		panic(flag.errorf("hostPortArg: unsupported type %T", v))
+	}
+}
+
+func (flag *Flag) ipArg(env string) string {
+	switch v := flag.envValue(env).(type) {
+	case string:
+		if _, err := parseAddr(v); err != nil {
+			panic(flag.errorf("ipArg: %s", err))
+		}
+
+		return fmt.Sprintf("netip.MustParseAddr(%q)", v)
+	case nil:
+		return "netip.Addr{}"
+	default:
+		panic(flag.errorf("ipArg: unsupported type %T", v))
+	}
+}
+
+func (flag *Flag) netSliceArg(env string) string {
+	var values []interface{}
+
+	switch v := flag.envValue(env).(type) {
+	case []interface{}:
+		values = v
+	case string:
+		values = []interface{}{v}
+	case nil:
+		// nothing
+	default:
+		panic(flag.errorf("netSliceArg: unsupported type %T", v))
+	}
+
+	args := make([]string, len(values))
+
+	for i, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			panic(flag.errorf("netSliceArg: unsupported value type %T", value))
+		}
+
+		var err error
+
+		if flag.Type == FlagTypeIPSlice {
+			_, err = parseAddr(s)
+			args[i] = fmt.Sprintf("netip.MustParseAddr(%q)", s)
+		} else {
+			_, err = parsePrefix(s)
+			args[i] = fmt.Sprintf("netip.MustParsePrefix(%q)", s)
+		}
+
+		if err != nil {
+			panic(flag.errorf("netSliceArg: %s", err))
+		}
+	}
+
+	return strings.Join(args, ", ")
+}
+
+func (flag *Flag) customGoType() string {
+	if flag.customImport == nil {
+		panic(flag.errorf("customGoType: unresolved goType %q", flag.CustomType))
+	}
+
+	return flag.customImport.Alias + "." + flag.CustomType[strings.LastIndex(flag.CustomType, ".")+1:]
+}
+
+func (flag *Flag) customText(env string) (string, bool) {
+	switch v := flag.envValue(env).(type) {
+	case string:
+		return v, true
+	case nil:
+		return "", false
+	default:
+		panic(flag.errorf("customText: value must be a string, got %T", v))
+	}
+}
+
+// validateCustomValue checks that defaults of custom flag are strings,
+// they are parsed by UnmarshalText of the type at runtime.
+func (flag *Flag) validateCustomValue() error {
+	values := []interface{}{flag.Value}
+	if m, ok := flag.Value.(map[string]interface{}); ok {
+		values = values[:0]
+
+		for _, v := range m {
+			values = append(values, v)
+		}
+	}
+
+	for _, v := range values {
+		switch v.(type) {
+		case string, nil:
+		default:
+			return errors.Errorf("value of custom flag %q must be a string, got %T", flag.Name, v)
+		}
+	}
+
+	return nil
+}
+
+func (flag *Flag) customArg(env string) string {
+	text, ok := flag.customText(env)
+	if !ok {
+		return nilStr
+	}
+
+	return fmt.Sprintf("MustParseText[%s](%q)", flag.GoType(), text)
+}
+
+func (flag *Flag) mapArg(env string) string {
+	value := flag.defaultValue(env)
+
+	var m map[string]interface{}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m = v
+	case nil:
+		return nilStr
+	default:
+		panic(flag.errorf("mapArg: unsupported type %T", value))
+	}
+
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+
+	for i, key := range keys {
+		var arg string
+
+		switch v := m[key].(type) {
+		case string:
+			if flag.Type != FlagTypeStringMap {
+				panic(flag.errorf("mapArg: unsupported value type %T of key %q", v, key))
+			}
+
+			arg = strconv.Quote(v)
+		case int:
+			arg = strconv.Itoa(v)
+			if flag.Type == FlagTypeStringMap {
+				arg = strconv.Quote(arg)
+			}
+		case float64:
+			if flag.Type != FlagTypeFloat64Map && flag.Type != FlagTypeStringMap {
+				panic(flag.errorf("mapArg: unsupported value type %T of key %q", v, key))
+			}
+
+			if flag.Type == FlagTypeFloat64Map && (math.IsNaN(v) || math.IsInf(v, 0)) {
+				panic(flag.errorf("mapArg: value %v of key %q is not finite", v, key))
+			}
+
+			arg = strconv.FormatFloat(v, 'f', -1, 64)
+			if flag.Type == FlagTypeStringMap {
+				arg = strconv.Quote(arg)
+			}
+		case bool:
+			if flag.Type != FlagTypeStringMap {
+				panic(flag.errorf("mapArg: unsupported value type %T of key %q", v, key))
+			}
+
+			arg = strconv.Quote(strconv.FormatBool(v))
+		default:
+			panic(flag.errorf("mapArg: unsupported value type %T of key %q", v, key))
+		}
+
+		pairs[i] = fmt.Sprintf("%q: %s", key, arg)
+	}
+
+	return fmt.Sprintf("%s{%s}", flag.GoType(), strings.Join(pairs, ", "))
+}
+
+func (flag *Flag) timestampArg(env string) string {
+	return flag.timeLiteral(env, flag.LocationExpr())
+}
+
+// timeLiteral returns Go expression of the timestamp value for env in the location expression loc.
+func (flag *Flag) timeLiteral(env, loc string) string {
+	dt := flag.timestampValue(env)
+	if dt.IsZero() {
+		return "time.Time{}"
+	}
+
+	switch {
+	case flag.Timezone != "Local":
+		dt = dt.In(flag.location())
+	case dt.Location() != localZone:
+		// the instant of the value with offset is converted to time.Local at runtime
+		dt = dt.UTC()
+
+		return fmt.Sprintf("time.Date(%d, %d, %d, %d, %d, %d, %d, time.UTC).In(%s)",
+			dt.Year(), dt.Month(), dt.Day(), dt.Hour(), dt.Minute(), dt.Second(), dt.Nanosecond(), loc,
+		)
+	}
+
+	return fmt.Sprintf("time.Date(%d, %d, %d, %d, %d, %d, %d, %s)",
+		dt.Year(), dt.Month(), dt.Day(), dt.Hour(), dt.Minute(), dt.Second(), dt.Nanosecond(), loc,
+	)
+}
+
+// timeLayouts contains layout constants of time package.
+var timeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+func (flag *Flag) layout() string {
+	if flag.Layout == "" {
+		return time.RFC3339
+	}
+
+	if layout, ok := timeLayouts[flag.Layout]; ok {
+		return layout
+	}
+
+	return flag.Layout
+}
+
+// LayoutExpr returns Go expression of timestamp layout.
+func (flag *Flag) LayoutExpr() string {
+	if flag.Layout == "" {
+		return "time.RFC3339"
+	}
+
+	if _, ok := timeLayouts[flag.Layout]; ok {
+		return "time." + flag.Layout
+	}
+
+	return strconv.Quote(flag.Layout)
+}
+
+// localZone is the location of timestamps which are parsed in time.Local at runtime, values without offset
+// are parsed in it at generation time, so they are kept as wall clock instead of time.Local of the generator.
+// The offset of one second is never used by values with offset.
+var localZone = time.FixedZone("Local", 1)
+
+func (flag *Flag) location() *time.Location {
+	switch flag.Timezone {
+	case "":
+		return time.UTC
+	case "Local":
+		return localZone
+	}
+
+	loc, err := time.LoadLocation(flag.Timezone)
+	if err != nil {
+		panic(flag.errorf("location: %s", err))
+	}
+
+	return loc
+}
+
+// LocationExpr returns Go expression of timestamp timezone.
+func (flag *Flag) LocationExpr() string {
+	return flag.locationExpr("MustLoadLocation")
+}
+
+// GoLocation is like LocationExpr but uses own helper of generated package of flag target lib.
+func (flag *Flag) GoLocation() string {
+	return flag.locationExpr("mustLoadLocation")
+}
+
+// locationExpr returns Go expression of timestamp timezone loaded by the load function.
+func (flag *Flag) locationExpr(load string) string {
+	switch flag.Timezone {
+	case "", "UTC":
+		return "time.UTC"
+	case "Local":
+		return "time.Local"
+	default:
+		// validate timezone name at generation time
+		flag.location()
+
+		return fmt.Sprintf("%s(%q)", load, flag.Timezone)
+	}
+}
+
+// HasTimezoneDB reports whether the flag requires IANA Time Zone database.
+func (flag *Flag) HasTimezoneDB() bool {
+	switch {
+	case flag.Type != FlagTypeTimestamp:
+		return false
+	case flag.Timezone == "", flag.Timezone == "UTC", flag.Timezone == "Local":
+		return false
+	default:
+		return true
+	}
+}
+
+// parseTimestamp parses default value with the layout of the flag,
+// values decoded by YAML as timestamp are formatted back to be parsed in flag timezone.
+func (flag *Flag) parseTimestamp(value interface{}) time.Time {
+	var s string
+
+	switch v := value.(type) {
+	case string:
+		s = v
+	case time.Time:
+		s = v.Format(flag.layout())
+	case nil:
+		return time.Time{}
+	default:
+		panic(flag.errorf("timestampArg: unsupported type %T", value))
+	}
+
+	dt, err := time.ParseInLocation(flag.layout(), s, flag.location())
+	if err != nil {
+		panic(flag.errorf("timestampArg: %s, value: %v", err, value))
+	}
+
+	return dt
+}
+
+func (flag *Flag) timestampValue(env string) time.Time {
+	return flag.parseTimestamp(flag.envValue(env))
+}
+
+func (flag *Flag) stringArg(env string) string {
+	var arg string
+
+	value := flag.envValue(env)
+	if value == nil {
+		return strconv.Quote("")
+	}
+
+	arg, ok := scalarString(value)
+	if !ok {
+		panic(flag.errorf("stringArg: unsupported value type %T", value))
+	}
+
+	return strconv.Quote(arg)
+}
+
+// scalarString formats scalar value decoded from YAML as it's written in YAML.
+func scalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+func isFiniteFloat(s string) bool {
+	f, err := strconv.ParseFloat(s, 64)
+
+	return err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+func (flag *Flag) errorf(format string, args ...interface{}) any {
+	msg := fmt.Sprintf(format, args...)
+	msg += fmt.Sprintf(" [flag=%s type=%s]", flag.Name, flag.Type)
+
+	return msg
+}
+
+type FlagType string
+
+const (
+	FlagTypeString        FlagType = "string"
+	FlagTypeStringSlice   FlagType = "stringSlice"
+	FlagTypeEnum          FlagType = "enum"
+	FlagTypeBool          FlagType = "bool"
+	FlagTypeInt           FlagType = "int"
+	FlagTypeUInt          FlagType = "uint"
+	FlagTypeInt32         FlagType = "int32"
+	FlagTypeUInt32        FlagType = "uint32"
+	FlagTypeInt64         FlagType = "int64"
+	FlagTypeUInt64        FlagType = "uint64"
+	FlagTypeIntSlice      FlagType = "intSlice"
+	FlagTypeUIntSlice     FlagType = "uintSlice"
+	FlagTypeInt64Slice    FlagType = "int64Slice"
+	FlagTypeUInt64Slice   FlagType = "uint64Slice"
+	FlagTypeFloat32       FlagType = "float32"
+	FlagTypeFloat64       FlagType = "float64"
+	FlagTypeFloat64Slice  FlagType = "float64Slice"
+	FlagTypeBoolSlice     FlagType = "boolSlice"
+	FlagTypeDurationSlice FlagType = "durationSlice"
+	FlagTypeEnumSlice     FlagType = "enumSlice"
+	FlagTypeDuration      FlagType = "duration"
+	FlagTypeTimestamp     FlagType = "timestamp"
+	FlagTypeBytes         FlagType = "bytes"
+	FlagTypeURL           FlagType = "url"
+	FlagTypeHostPort      FlagType = "hostPort"
+	FlagTypeIP            FlagType = "ip"
+	FlagTypeIPSlice       FlagType = "ipSlice"
+	FlagTypeCIDRSlice     FlagType = "cidrSlice"
+	FlagTypeStringMap     FlagType = "stringMap"
+	FlagTypeIntMap        FlagType = "intMap"
+	FlagTypeInt64Map      FlagType = "int64Map"
+	FlagTypeFloat64Map    FlagType = "float64Map"
+	FlagTypeCustom        FlagType = "custom"
+)
+
+func (ft FlagType) String() string {
+	return string(ft)
+}
+
+func parseAddr(s string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(s)
+
+	return addr, errors.Wrapf(err, "invalid ip %q", s)
+}
+
+func parsePrefix(s string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(s)
+
+	return prefix, errors.Wrapf(err, "invalid cidr %q", s)
+}
@@ -0,0 +1,50 @@
+package codegen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlag_mapArg(t *testing.T) {
+	flag := &Flag{
+		Name:   "limits",
+		Type:   FlagTypeIntMap,
+		Value:  map[string]interface{}{"a": 1},
+		Values: map[string]interface{}{"prod": map[string]interface{}{"a": 1000}},
+	}
+
+	assert.Equal(t, `map[string]int{"a": 1000}`, flag.mapArg("prod"))
+	assert.Equal(t, `map[string]int{"a": 1}`, flag.mapArg("test"))
+}
+
+func TestFlag_timestampArg_local(t *testing.T) {
+	// generated code doesn't depend on the timezone of the generator
+	local := time.Local
+	time.Local = time.FixedZone("Generator", 5*60*60)
+
+	t.Cleanup(func() {
+		time.Local = local
+	})
+
+	flag := &Flag{Name: "start", Type: FlagTypeTimestamp, Layout: "2006-01-02 15:04", Timezone: "Local", Value: "2024-01-31 08:30"}
+	assert.Equal(t, "time.Date(2024, 1, 31, 8, 30, 0, 0, time.Local)", flag.Args("local"))
+
+	flag = &Flag{Name: "start", Type: FlagTypeTimestamp, Timezone: "Local", Value: "2024-01-31T08:30:00+02:00"}
+	assert.Equal(t, "time.Date(2024, 1, 31, 6, 30, 0, 0, time.UTC).In(time.Local)", flag.Args("local"))
+}
+
+func TestFlag_HelpDefaultText(t *testing.T) {
+	flag := &Flag{Name: "password", Type: FlagTypeString, Secret: true}
+	assert.Empty(t, flag.HelpDefaultText())
+
+	flag.Value = "qwerty"
+	assert.Equal(t, redactedText, flag.HelpDefaultText())
+
+	flag.DefaultText = "from vault"
+	assert.Equal(t, "from vault", flag.HelpDefaultText())
+
+	flag = &Flag{Name: "host", Type: FlagTypeString, Value: "localhost"}
+	assert.Empty(t, flag.HelpDefaultText())
+}
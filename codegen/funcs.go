@@ -0,0 +1,99 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/iancoleman/strcase"
+)
+
+// TemplateFuncs returns built-in functions of config templates for given source:
+//
+//	toCamel, toSnake, toKebab, toLower  convert case of the string, toSnake returns SCREAMING_SNAKE_CASE
+//	quote                               returns Go string literal
+//	join sep list                       joins list of strings by sep
+//	indent n text                       indents each non-empty line of text by n tabs
+//	goLiteral value                     returns Go literal of string, number, bool, slice or map value
+//	envVars flag                        returns environment variables of the flag
+//	imports                             returns imports of custom flag types
+//	hasDateTimeFlags, hasNetFlags, hasURLFlags, hasTimezoneDB, hasCustomFlags, hasDeprecated
+//	                                    report whether the source has flags which need the imports
+//	hasHiddenFlags                      reports whether the source has hidden or renamed flags
+//	hasRenamedFlags                     reports whether the source has renamed flags
+func TemplateFuncs(source *Source) template.FuncMap {
+	return template.FuncMap{
+		"toCamel":          strcase.ToCamel,
+		"toSnake":          strcase.ToScreamingSnake,
+		"toKebab":          strcase.ToKebab,
+		"toLower":          strings.ToLower,
+		"quote":            strconv.Quote,
+		"join":             join,
+		"indent":           indent,
+		"goLiteral":        goLiteral,
+		"envVars":          func(flag *Flag) []string { return flag.EnvVars(source.App.EnvVarPrefix()) },
+		"hasDateTimeFlags": source.Flags.HasDateTimeFlags,
+		"hasNetFlags":      source.Flags.HasNetFlags,
+		"hasURLFlags":      source.Flags.HasURLFlags,
+		"hasTimezoneDB":    source.Flags.HasTimezoneDB,
+		"hasCustomFlags":   source.Flags.HasCustomFlags,
+		"hasDeprecated":    source.Flags.HasDeprecatedFlags,
+		"hasHiddenFlags":   source.Flags.HasHiddenFlags,
+		"hasRenamedFlags":  source.Flags.HasRenamedFlags,
+		"imports":          source.Flags.Imports,
+	}
+}
+
+func join(sep string, elems []string) string {
+	return strings.Join(elems, sep)
+}
+
+func indent(n int, text string) string {
+	prefix := strings.Repeat("\t", n)
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// goLiteral returns Go literal of the value decoded from YAML.
+func goLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return nilStr
+	case string:
+		return strconv.Quote(v)
+	case []interface{}:
+		elems := make([]string, len(v))
+		for i, e := range v {
+			elems[i] = goLiteral(e)
+		}
+
+		return "[]interface{}{" + strings.Join(elems, ", ") + "}"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		elems := make([]string, len(keys))
+
+		for i, key := range keys {
+			elems[i] = strconv.Quote(key) + ": " + goLiteral(v[key])
+		}
+
+		return "map[string]interface{}{" + strings.Join(elems, ", ") + "}"
+	default:
+		return fmt.Sprintf("%#v", v)
+	}
+}
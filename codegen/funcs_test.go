@@ -0,0 +1,23 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoLiteral(t *testing.T) {
+	assert.Equal(t, "nil", goLiteral(nil))
+	assert.Equal(t, `"a\"b"`, goLiteral(`a"b`))
+	assert.Equal(t, "1", goLiteral(1))
+	assert.Equal(t, "0.75", goLiteral(0.75))
+	assert.Equal(t, "true", goLiteral(true))
+	assert.Equal(t, `[]string{"A", "B"}`, goLiteral([]string{"A", "B"}))
+	assert.Equal(t, `[]interface{}{"a", 1}`, goLiteral([]interface{}{"a", 1}))
+	assert.Equal(t, `map[string]interface{}{"a": 1, "b": "c"}`, goLiteral(map[string]interface{}{"b": "c", "a": 1}))
+}
+
+func TestIndent(t *testing.T) {
+	assert.Equal(t, "\t\ta\n\n\t\tb", indent(2, "a\n\nb"))
+	assert.Equal(t, "a, b", join(", ", []string{"a", "b"}))
+}
@@ -0,0 +1,75 @@
+package codegen
+
+import (
+	"bytes"
+	"go/importer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FuzzCodegen decodes arbitrary YAML and renders it by each built-in template,
+// the source must be rejected with an error or produce Go code which passes type checking.
+// Crashers are stored in testdata/fuzz/FuzzCodegen.
+func FuzzCodegen(f *testing.F) {
+	sources, _ := filepath.Glob(filepath.Join("testdata", "*", "*.yaml"))
+
+	for _, source := range append(sources, filepath.Join("..", "config.example.yaml")) {
+		b, err := os.ReadFile(source)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(b)
+	}
+
+	// edge values which are accepted by YAML but can't be represented by Go literals
+	f.Add([]byte(`
+app: { name: app, env: [ local ] }
+flags:
+  ratio: { type: float32, value: 1e39 }
+`))
+	f.Add([]byte(`
+app: { name: app, env: [ local ] }
+flags:
+  weights: { type: float64Map, value: { a: .nan, b: -.inf } }
+`))
+
+	imp := importer.ForCompiler(token.NewFileSet(), "source", nil)
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		source := new(Source)
+		if yaml.NewDecoder(bytes.NewReader(b)).Decode(source) != nil {
+			return
+		}
+
+		for _, lib := range targetLibs() {
+			gen := &Codegen{source: source, PackageName: "config", TargetLib: lib, Tests: true}
+			if gen.checkSource() != nil {
+				continue
+			}
+
+			tpl, err := gen.readTemplate("")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			file, err := gen.execute(tpl, "config.go")
+			if err != nil {
+				continue
+			}
+
+			files := map[string][]byte{file.path: file.content}
+
+			file, err = gen.generateTests(testFile)
+			if err == nil {
+				files[file.path] = file.content
+			}
+
+			typeCheck(t, imp, filepath.Join("testdata", "golden"), files)
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"text/template"
+
+	config "github.com/partyzanex/cli-config-gen"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const initTemplate = "config_init.tpl"
+
+// Init writes the starter source config file.
+type Init struct {
+	// SourceFile is the path of the written source config file.
+	SourceFile string
+	// Name is the app name.
+	Name string
+	// Desc is the app description.
+	Desc string
+	// Env contains environment names, the first one is default.
+	Env []string
+	// From is the package pattern, e.g. ./cmd/app, flags of the source are converted
+	// from urfave/cli flags slices and struct fields with `flag` tags found in the package.
+	From string
+	// Dir is the directory in which the package pattern is resolved, the current directory by default.
+	Dir string
+	// Force enables overwriting of the existing source file.
+	Force bool
+
+	// Warnings contains messages about declarations of the package which were not converted.
+	Warnings []string
+}
+
+func (i *Init) Run() error {
+	if _, err := os.Stat(i.SourceFile); err == nil && !i.Force {
+		return errors.Errorf("source file %s already exists", i.SourceFile)
+	}
+
+	if len(i.Env) == 0 {
+		return errors.New("at least one environment is required")
+	}
+
+	var (
+		content []byte
+		err     error
+	)
+
+	if i.From == "" {
+		content, err = i.starter()
+	} else {
+		content, err = i.fromPackage()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	err = yaml.Unmarshal(content, new(Source))
+	if err != nil {
+		return errors.Wrap(err, "invalid source")
+	}
+
+	return writeFiles([]*generatedFile{{path: i.SourceFile, content: content}})
+}
+
+// starter renders the commented source with an example of each flag type.
+func (i *Init) starter() ([]byte, error) {
+	b, err := TemplateFS.ReadFile(initTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read template")
+	}
+
+	tpl, err := template.New(initTemplate).Funcs(template.FuncMap{"quote": strconv.Quote}).Parse(string(b))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse template")
+	}
+
+	app := &App{Name: i.Name}
+
+	var buf bytes.Buffer
+
+	err = tpl.Execute(&buf, map[string]interface{}{
+		"SourceFile": i.SourceFile,
+		"Name":       i.Name,
+		"Desc":       i.Desc,
+		"Env":        i.Env,
+		"EnvPrefix":  app.EnvVarPrefix(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot execute template")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// fromPackage converts flags found in the package.
+func (i *Init) fromPackage() ([]byte, error) {
+	flags, warnings, err := loadPackageFlags(i.Dir, i.From, (&App{Name: i.Name}).EnvVarPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	i.Warnings = warnings
+
+	if len(flags) == 0 {
+		return nil, errors.Errorf("no flags found in %s", i.From)
+	}
+
+	source := &Source{
+		App:   App{Name: i.Name, Desc: i.Desc},
+		Flags: flags,
+	}
+
+	for _, env := range i.Env {
+		source.App.Env = append(source.App.Env, &Environment{Name: config.EnvName(env)})
+	}
+
+	i.setGoNames(source)
+
+	return encodeSource("Converted from "+i.From+" by cli-config-gen init.", source)
+}
+
+// goNameSuffix is appended to Go names of converted flags which clash with identifiers of generated code.
+const goNameSuffix = "Opt"
+
+// setGoNames sets goName of flags which identifiers clash with identifiers of generated code of any target lib.
+func (i *Init) setGoNames(source *Source) {
+	for clashes := true; clashes; {
+		clashes = false
+
+		declareSymbols(&source.App, source.Flags, true, func(flag *Flag, _ string, _ error) {
+			if flag == nil || clashes {
+				return
+			}
+
+			name := flag.GoIdent()
+			flag.GoName = name + goNameSuffix
+			clashes = true
+
+			i.Warnings = append(i.Warnings, fmt.Sprintf("Go name %s of flag %q clashes with generated code, goName %s is set",
+				name, flag.Name, flag.GoName))
+		})
+	}
+}
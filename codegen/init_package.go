@@ -0,0 +1,585 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+// cliFlagTypes maps urfave/cli v2 and v3 flag types to flag types.
+var cliFlagTypes = map[string]FlagType{
+	"StringFlag":       FlagTypeString,
+	"PathFlag":         FlagTypeString,
+	"BoolFlag":         FlagTypeBool,
+	"IntFlag":          FlagTypeInt,
+	"Int32Flag":        FlagTypeInt32,
+	"Int64Flag":        FlagTypeInt64,
+	"UintFlag":         FlagTypeUInt,
+	"Uint32Flag":       FlagTypeUInt32,
+	"Uint64Flag":       FlagTypeUInt64,
+	"Float32Flag":      FlagTypeFloat32,
+	"Float64Flag":      FlagTypeFloat64,
+	"FloatFlag":        FlagTypeFloat64,
+	"DurationFlag":     FlagTypeDuration,
+	"TimestampFlag":    FlagTypeTimestamp,
+	"StringSliceFlag":  FlagTypeStringSlice,
+	"IntSliceFlag":     FlagTypeIntSlice,
+	"Int64SliceFlag":   FlagTypeInt64Slice,
+	"UintSliceFlag":    FlagTypeUIntSlice,
+	"Uint64SliceFlag":  FlagTypeUInt64Slice,
+	"Float64SliceFlag": FlagTypeFloat64Slice,
+	"FloatSliceFlag":   FlagTypeFloat64Slice,
+	"StringMapFlag":    FlagTypeStringMap,
+}
+
+const cliPkgPrefix = "github.com/urfave/cli/"
+
+// packageScanner converts flags declared in Go packages.
+type packageScanner struct {
+	pkg       *packages.Package
+	envPrefix string
+	flags     Flags
+	names     map[string]bool
+	warnings  []string
+}
+
+// loadPackageFlags loads packages matched by pattern and converts their urfave/cli flags slices
+// and struct fields with `flag` tags, envPrefix is the prefix of automatic environment variables of the app.
+func loadPackageFlags(dir, pattern, envPrefix string) (Flags, []string, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo |
+			packages.NeedImports | packages.NeedDeps,
+		Dir: dir,
+	}, pattern)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "cannot load package %s", pattern)
+	}
+
+	scanner := &packageScanner{envPrefix: envPrefix, names: make(map[string]bool)}
+
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			return nil, nil, errors.Errorf("cannot load package %s: %s", pattern, e)
+		}
+
+		scanner.pkg = pkg
+
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, scanner.inspect)
+		}
+	}
+
+	return scanner.flags, scanner.warnings, nil
+}
+
+func (s *packageScanner) inspect(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.CompositeLit:
+		if s.isCLIFlags(n) {
+			for _, elt := range n.Elts {
+				s.convertCLIFlag(elt)
+			}
+
+			return false
+		}
+	case *ast.StructType:
+		for _, field := range n.Fields.List {
+			s.convertField(field)
+		}
+	}
+
+	return true
+}
+
+func (s *packageScanner) warnf(node ast.Node, format string, args ...interface{}) {
+	s.warnings = append(s.warnings, fmt.Sprintf("%s: %s", s.pkg.Fset.Position(node.Pos()), fmt.Sprintf(format, args...)))
+}
+
+func (s *packageScanner) add(node ast.Node, flag *Flag) {
+	if flag.Name == "" {
+		s.warnf(node, "flag without name is skipped")
+
+		return
+	}
+
+	if s.names[flag.Name] {
+		s.warnf(node, "duplicate flag %q is skipped", flag.Name)
+
+		return
+	}
+
+	s.names[flag.Name] = true
+	s.flags = append(s.flags, flag)
+}
+
+// isCLIFlags reports whether the literal is a slice of urfave/cli Flag interface.
+func (s *packageScanner) isCLIFlags(lit *ast.CompositeLit) bool {
+	slice, ok := s.pkg.TypesInfo.TypeOf(lit).(*types.Slice)
+	if !ok {
+		return false
+	}
+
+	named, ok := slice.Elem().(*types.Named)
+
+	return ok && named.Obj().Name() == "Flag" && named.Obj().Pkg() != nil &&
+		strings.HasPrefix(named.Obj().Pkg().Path(), cliPkgPrefix)
+}
+
+// convertCLIFlag converts &cli.XFlag{...} element of flags slice.
+func (s *packageScanner) convertCLIFlag(expr ast.Expr) {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		s.warnf(expr, "flag %s is not a composite literal", types.ExprString(expr))
+
+		return
+	}
+
+	sel, ok := lit.Type.(*ast.SelectorExpr)
+	if !ok {
+		s.warnf(expr, "flag type %s is not supported", types.ExprString(lit.Type))
+
+		return
+	}
+
+	flagType, ok := cliFlagTypes[sel.Sel.Name]
+	if !ok {
+		s.warnf(expr, "flag type %s is not supported", types.ExprString(lit.Type))
+
+		return
+	}
+
+	flag := &Flag{Type: flagType, TakesFile: sel.Sel.Name == "PathFlag"}
+
+	var envVars []string
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		switch key.Name {
+		case "Name":
+			flag.Name, _ = s.stringValue(kv.Value)
+		case "Usage":
+			flag.Desc, _ = s.stringValue(kv.Value)
+		case "Aliases":
+			flag.Aliases = s.strings(kv.Value)
+		case "EnvVars":
+			envVars = s.strings(kv.Value)
+		case "Sources":
+			envVars = s.envSources(kv.Value)
+		case "Required":
+			flag.Required = s.boolValue(kv.Value)
+		case "Hidden":
+			flag.Hidden = s.boolValue(kv.Value)
+		case "TakesFile":
+			flag.TakesFile = flag.TakesFile || s.boolValue(kv.Value)
+		case "DefaultText":
+			flag.DefaultText, _ = s.stringValue(kv.Value)
+		case "Value":
+			flag.Value = s.cliValue(flag, kv.Value)
+		default: // other fields are not converted
+		}
+	}
+
+	s.setEnv(flag, envVars)
+	s.add(lit, flag)
+}
+
+// setEnv sets environment variables of the flag, the automatic variable of the app is omitted.
+func (s *packageScanner) setEnv(flag *Flag, envVars []string) {
+	if len(envVars) == 0 {
+		flag.Env = false
+
+		return
+	}
+
+	if envVars[0] == s.envPrefix+strcase.ToScreamingSnake(flag.Name) {
+		envVars = envVars[1:]
+	} else {
+		flag.EnvPrimary = new(bool)
+	}
+
+	flag.EnvExact = envVars
+}
+
+// cliValue converts the Value field of urfave/cli flag.
+func (s *packageScanner) cliValue(flag *Flag, expr ast.Expr) interface{} {
+	switch flag.Type {
+	case FlagTypeStringSlice, FlagTypeIntSlice, FlagTypeInt64Slice, FlagTypeUIntSlice, FlagTypeUInt64Slice,
+		FlagTypeFloat64Slice:
+		var elems []ast.Expr
+
+		switch v := expr.(type) {
+		case *ast.CallExpr: // cli.NewStringSlice("a", "b")
+			elems = v.Args
+		case *ast.CompositeLit: // []string{"a", "b"}
+			elems = v.Elts
+		default:
+			s.warnf(expr, "value %s of flag %q is not converted", types.ExprString(expr), flag.Name)
+
+			return nil
+		}
+
+		values := make([]interface{}, 0, len(elems))
+
+		for _, elem := range elems {
+			value, ok := s.constValue(elem)
+			if !ok {
+				s.warnf(expr, "value %s of flag %q is not converted", types.ExprString(expr), flag.Name)
+
+				return nil
+			}
+
+			values = append(values, value)
+		}
+
+		return values
+	case FlagTypeStringMap:
+		lit, ok := expr.(*ast.CompositeLit)
+		if !ok {
+			s.warnf(expr, "value %s of flag %q is not converted", types.ExprString(expr), flag.Name)
+
+			return nil
+		}
+
+		values := make(map[string]interface{}, len(lit.Elts))
+
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+
+			key, keyOK := s.stringValue(kv.Key)
+			value, valueOK := s.stringValue(kv.Value)
+
+			if !keyOK || !valueOK {
+				s.warnf(expr, "value %s of flag %q is not converted", types.ExprString(expr), flag.Name)
+
+				return nil
+			}
+
+			values[key] = value
+		}
+
+		return values
+	case FlagTypeTimestamp:
+		s.warnf(expr, "value of timestamp flag %q is not converted", flag.Name)
+
+		return nil
+	}
+
+	value, ok := s.constValue(expr)
+	if !ok {
+		s.warnf(expr, "value %s of flag %q is not converted", types.ExprString(expr), flag.Name)
+
+		return nil
+	}
+
+	if flag.Type == FlagTypeDuration {
+		if ns, ok := value.(int64); ok {
+			return time.Duration(ns).String()
+		}
+	}
+
+	return value
+}
+
+// envSources returns environment variables of urfave/cli v3 cli.EnvVars("A", "B") sources.
+func (s *packageScanner) envSources(expr ast.Expr) []string {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		s.warnf(expr, "sources %s are not converted", types.ExprString(expr))
+
+		return nil
+	}
+
+	if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "EnvVars" {
+		s.warnf(expr, "sources %s are not converted", types.ExprString(expr))
+
+		return nil
+	}
+
+	envVars := make([]string, 0, len(call.Args))
+
+	for _, arg := range call.Args {
+		if env, ok := s.stringValue(arg); ok {
+			envVars = append(envVars, env)
+		}
+	}
+
+	return envVars
+}
+
+// strings returns constant strings of []string{...} literal.
+func (s *packageScanner) strings(expr ast.Expr) []string {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		s.warnf(expr, "%s is not converted", types.ExprString(expr))
+
+		return nil
+	}
+
+	values := make([]string, 0, len(lit.Elts))
+
+	for _, elt := range lit.Elts {
+		if value, ok := s.stringValue(elt); ok {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func (s *packageScanner) stringValue(expr ast.Expr) (string, bool) {
+	value, ok := s.constValue(expr)
+	str, isString := value.(string)
+
+	return str, ok && isString
+}
+
+func (s *packageScanner) boolValue(expr ast.Expr) bool {
+	value, _ := s.constValue(expr)
+	b, _ := value.(bool)
+
+	return b
+}
+
+// constValue returns the value of constant expression as string, int64, uint64, float64 or bool.
+func (s *packageScanner) constValue(expr ast.Expr) (interface{}, bool) {
+	tv, ok := s.pkg.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil {
+		return nil, false
+	}
+
+	value := tv.Value
+
+	switch value.Kind() {
+	case constant.String:
+		return constant.StringVal(value), true
+	case constant.Bool:
+		return constant.BoolVal(value), true
+	case constant.Int:
+		if i, exact := constant.Int64Val(value); exact {
+			return i, true
+		}
+
+		if u, exact := constant.Uint64Val(value); exact {
+			return u, true
+		}
+	case constant.Float:
+		f, _ := constant.Float64Val(value)
+
+		return f, true
+	default: // nothing
+	}
+
+	return nil, false
+}
+
+// convertField converts struct field with `flag:"name"` tag, other supported tags are
+// `env:"A,B"`, `default:"value"`, `usage:"text"` and `required:"true"`.
+func (s *packageScanner) convertField(field *ast.Field) {
+	if field.Tag == nil {
+		return
+	}
+
+	tagValue, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return
+	}
+
+	tag := reflect.StructTag(tagValue)
+
+	name, ok := tag.Lookup("flag")
+	if !ok || name == "" || name == "-" {
+		return
+	}
+
+	flag := &Flag{Name: name, Desc: tag.Get("usage")}
+
+	flag.Type, flag.CustomType = s.fieldType(s.pkg.TypesInfo.TypeOf(field.Type))
+	if flag.Type == "" {
+		s.warnf(field, "type %s of flag %q is not supported", types.ExprString(field.Type), name)
+
+		return
+	}
+
+	flag.Required, _ = strconv.ParseBool(tag.Get("required"))
+
+	if env, ok := tag.Lookup("env"); ok {
+		var envVars []string
+		if env != "" {
+			envVars = splitList(env)
+		}
+
+		s.setEnv(flag, envVars)
+	}
+
+	if def, ok := tag.Lookup("default"); ok {
+		value, err := parseDefault(flag.Type, def)
+		if err != nil {
+			s.warnf(field, "default %q of flag %q is not converted: %s", def, name, err)
+		}
+
+		flag.Value = value
+	}
+
+	s.add(field, flag)
+}
+
+var basicFlagTypes = map[types.BasicKind]FlagType{
+	types.String:  FlagTypeString,
+	types.Bool:    FlagTypeBool,
+	types.Int:     FlagTypeInt,
+	types.Int32:   FlagTypeInt32,
+	types.Int64:   FlagTypeInt64,
+	types.Uint:    FlagTypeUInt,
+	types.Uint32:  FlagTypeUInt32,
+	types.Uint64:  FlagTypeUInt64,
+	types.Float32: FlagTypeFloat32,
+	types.Float64: FlagTypeFloat64,
+}
+
+var namedFlagTypes = map[string]FlagType{
+	"time.Duration":      FlagTypeDuration,
+	"time.Time":          FlagTypeTimestamp,
+	"*net/url.URL":       FlagTypeURL,
+	"net/netip.Addr":     FlagTypeIP,
+	"[]net/netip.Addr":   FlagTypeIPSlice,
+	"[]net/netip.Prefix": FlagTypeCIDRSlice,
+	"[]string":           FlagTypeStringSlice,
+	"[]int":              FlagTypeIntSlice,
+	"[]int64":            FlagTypeInt64Slice,
+	"[]uint":             FlagTypeUIntSlice,
+	"[]uint64":           FlagTypeUInt64Slice,
+	"[]float64":          FlagTypeFloat64Slice,
+	"[]bool":             FlagTypeBoolSlice,
+	"[]time.Duration":    FlagTypeDurationSlice,
+	"map[string]string":  FlagTypeStringMap,
+	"map[string]int":     FlagTypeIntMap,
+	"map[string]int64":   FlagTypeInt64Map,
+	"map[string]float64": FlagTypeFloat64Map,
+}
+
+// fieldType returns the flag type of Go type, named types which implement encoding.TextUnmarshaler
+// by pointer are converted to custom flags.
+func (s *packageScanner) fieldType(typ types.Type) (FlagType, string) {
+	if typ == nil {
+		return "", ""
+	}
+
+	if flagType, ok := namedFlagTypes[types.TypeString(typ, nil)]; ok {
+		return flagType, ""
+	}
+
+	if named, ok := typ.(*types.Named); ok && named.Obj().Pkg() != nil && isTextUnmarshaler(named) {
+		return FlagTypeCustom, named.Obj().Pkg().Path() + "." + named.Obj().Name()
+	}
+
+	if basic, ok := typ.Underlying().(*types.Basic); ok {
+		return basicFlagTypes[basic.Kind()], ""
+	}
+
+	return "", ""
+}
+
+func isTextUnmarshaler(named *types.Named) bool {
+	method, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), false, named.Obj().Pkg(), "UnmarshalText")
+
+	fn, ok := method.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig := fn.Type().(*types.Signature)
+
+	return sig.Params().Len() == 1 && sig.Results().Len() == 1
+}
+
+// parseDefault parses the default value of struct tag by flag type.
+func parseDefault(flagType FlagType, def string) (interface{}, error) {
+	switch flagType {
+	case FlagTypeStringSlice, FlagTypeIntSlice, FlagTypeInt64Slice, FlagTypeUIntSlice, FlagTypeUInt64Slice,
+		FlagTypeFloat64Slice, FlagTypeBoolSlice, FlagTypeDurationSlice, FlagTypeIPSlice, FlagTypeCIDRSlice:
+		elemType := FlagType(strings.TrimSuffix(string(flagType), "Slice"))
+		values := make([]interface{}, 0)
+		if def == "" {
+			return values, nil
+		}
+
+		for _, elem := range splitList(def) {
+			value, err := parseDefault(elemType, elem)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, value)
+		}
+
+		return values, nil
+	case FlagTypeStringMap, FlagTypeIntMap, FlagTypeInt64Map, FlagTypeFloat64Map:
+		elemType := FlagType(strings.TrimSuffix(string(flagType), "Map"))
+		values := make(map[string]interface{})
+		if def == "" {
+			return values, nil
+		}
+
+		for _, pair := range splitList(def) {
+			key, elem, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, errors.Errorf("invalid key-value pair %q", pair)
+			}
+
+			value, err := parseDefault(elemType, elem)
+			if err != nil {
+				return nil, err
+			}
+
+			values[key] = value
+		}
+
+		return values, nil
+	case FlagTypeInt, FlagTypeInt32, FlagTypeInt64:
+		return strconv.ParseInt(def, 10, 64)
+	case FlagTypeUInt, FlagTypeUInt32, FlagTypeUInt64:
+		return strconv.ParseUint(def, 10, 64)
+	case FlagTypeFloat32, FlagTypeFloat64:
+		return strconv.ParseFloat(def, 64)
+	case FlagTypeBool:
+		return strconv.ParseBool(def)
+	default:
+		return def, nil
+	}
+}
+
+// splitList splits comma separated list of values.
+func splitList(s string) []string {
+	parts := strings.Split(s, ",")
+
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+
+	return parts
+}
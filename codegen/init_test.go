@@ -0,0 +1,51 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInit_Run(t *testing.T) {
+	dir := t.TempDir()
+	init := &Init{
+		SourceFile: filepath.Join(dir, "config.yaml"),
+		Name:       "simple-app",
+		Env:        []string{"local", "prod"},
+	}
+
+	assert.NoError(t, init.Run())
+	assert.Error(t, init.Run(), "existing file is overwritten")
+
+	issues, err := (&Lint{SourceFile: init.SourceFile}).Issues()
+	assert.NoError(t, err)
+	assert.Empty(t, issues, "starter config has lint issues")
+
+	for _, lib := range targetLibs() {
+		gen := &Codegen{
+			SourceFile:  init.SourceFile,
+			TargetPath:  filepath.Join(dir, filepath.FromSlash(lib), "config.go"),
+			PackageName: "config",
+			TargetLib:   lib,
+		}
+		assert.NoError(t, gen.Run(), lib)
+	}
+}
+
+func TestInit_Run_from(t *testing.T) {
+	init := &Init{
+		SourceFile: filepath.Join(t.TempDir(), "config.yaml"),
+		Name:       "svc",
+		Env:        []string{"local"},
+		From:       "./testdata/init/flags",
+	}
+
+	assert.NoError(t, init.Run())
+	assert.Len(t, init.Warnings, 4)
+
+	b, err := os.ReadFile(init.SourceFile)
+	assert.NoError(t, err)
+	assertGolden(t, filepath.Join("testdata", "init", "flags.yaml"), b)
+}
@@ -0,0 +1,452 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is the severity of lint issues of the rule.
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// LintConfig is the lint section of the source config.
+type LintConfig struct {
+	// Rules overrides severities of rules by rule names, "off" disables the rule.
+	Rules map[string]Severity `yaml:"rules"`
+}
+
+// Lint formats.
+const (
+	LintFormatText  = "text"
+	LintFormatJSON  = "json"
+	LintFormatSARIF = "sarif"
+)
+
+// Lint checks the source config for issues which are accepted by the generator
+// but produce clashing names or unexpected behaviour.
+type Lint struct {
+	// SourceFile is the path of the source config file.
+	SourceFile string
+	// Rules overrides severities of rules of the lint section of the source.
+	Rules map[string]Severity
+	// Format is the format of the report: text, json or sarif, text by default.
+	Format string
+}
+
+// Issue is the problem of the source found by the lint rule.
+type Issue struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Flag is the name of the flag, it's empty for issues of the source.
+	Flag string `json:"flag,omitempty"`
+	File string `json:"file"`
+	// Line and Column are positions in the source, they are zero if the position is unknown.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+func (issue *Issue) String() string {
+	pos := issue.File
+	if issue.Line > 0 {
+		pos += fmt.Sprintf(":%d:%d", issue.Line, issue.Column)
+	}
+
+	return fmt.Sprintf("%s: %s: %s [%s]", pos, issue.Severity, issue.Message, issue.Rule)
+}
+
+// lintRule checks the decoded source.
+type lintRule struct {
+	name     string
+	desc     string
+	severity Severity
+	check    func(src *lintSource, report lintReport)
+}
+
+// lintRules contains rules of Lint with default severities.
+var lintRules = []*lintRule{
+	{
+		name:     "invalid-source",
+		desc:     "Source config is rejected by the generator",
+		severity: SeverityError,
+		check:    checkInvalidSource,
+	},
+	{
+		name:     "duplicate-alias",
+		desc:     "Alias is declared by several flags",
+		severity: SeverityError,
+		check:    checkDuplicateAlias,
+	},
+	{
+		name:     "alias-clash",
+		desc:     "Alias is the name of a flag",
+		severity: SeverityError,
+		check:    checkAliasClash,
+	},
+	{
+		name:     "go-name-clash",
+		desc:     "Go identifiers of flags clash with each other or with built-in identifiers",
+		severity: SeverityError,
+		check:    checkGoNameClash,
+	},
+	{
+		name:     "env-var-clash",
+		desc:     "Environment variable is read by several flags",
+		severity: SeverityError,
+		check:    checkEnvVarClash,
+	},
+	{
+		name:     "enum-const-clash",
+		desc:     "Enum constants clash with other Go identifiers of generated code",
+		severity: SeverityError,
+		check:    checkEnumConstClash,
+	},
+	{
+		name:     "missing-desc",
+		desc:     "Flag has no description shown in help",
+		severity: SeverityWarning,
+		check:    checkMissingDesc,
+	},
+	{
+		name:     "required-default",
+		desc:     "Required flag has a default value which is never used",
+		severity: SeverityWarning,
+		check:    checkRequiredDefault,
+	},
+}
+
+// lintSource is the source decoded without validation, flags keep their positions.
+type lintSource struct {
+	app   App
+	flags []*lintFlag
+	lint  LintConfig
+	// err is the error of decoding of the source by the generator.
+	err error
+	// flagErrs contains errors of decoding and validation of flags.
+	flagErrs []*lintError
+}
+
+type lintFlag struct {
+	*Flag
+
+	line, column int
+}
+
+type lintError struct {
+	err  error
+	flag *lintFlag
+}
+
+// Run checks the source and writes the report to w, it returns an error if issues of error severity are found.
+func (l *Lint) Run(w io.Writer) error {
+	issues, err := l.Issues()
+	if err != nil {
+		return err
+	}
+
+	err = writeLintReport(w, l.Format, l.SourceFile, issues)
+	if err != nil {
+		return err
+	}
+
+	var count int
+
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			count++
+		}
+	}
+
+	if count > 0 {
+		return errors.Errorf("%d lint issues of error severity found", count)
+	}
+
+	return nil
+}
+
+// Issues checks the source and returns found issues ordered by positions.
+func (l *Lint) Issues() ([]*Issue, error) {
+	content, err := os.ReadFile(l.SourceFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read source config file")
+	}
+
+	src, err := decodeLintSource(content)
+	if err != nil {
+		return nil, err
+	}
+
+	severities, err := l.severities(src.lint.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []*Issue
+
+	for _, rule := range lintRules {
+		severity := severities[rule.name]
+		if severity == SeverityOff {
+			continue
+		}
+
+		rule.check(src, func(flag *lintFlag, format string, args ...interface{}) {
+			issue := &Issue{
+				Rule:     rule.name,
+				Severity: severity,
+				Message:  fmt.Sprintf(format, args...),
+				File:     l.SourceFile,
+			}
+
+			if flag != nil {
+				issue.Flag, issue.Line, issue.Column = flag.Name, flag.line, flag.column
+			}
+
+			issues = append(issues, issue)
+		})
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+
+	return issues, nil
+}
+
+// severities returns severities of rules, the lint section of the source is overridden by Rules.
+func (l *Lint) severities(source map[string]Severity) (map[string]Severity, error) {
+	severities := make(map[string]Severity, len(lintRules))
+
+	for _, rule := range lintRules {
+		severities[rule.name] = rule.severity
+	}
+
+	for _, rules := range []map[string]Severity{source, l.Rules} {
+		for name, severity := range rules {
+			if _, ok := severities[name]; !ok {
+				return nil, errors.Errorf("unknown lint rule %q", name)
+			}
+
+			switch severity {
+			case SeverityOff, SeverityWarning, SeverityError:
+			default:
+				return nil, errors.Errorf("invalid severity %q of lint rule %q, expected off, warning or error", severity, name)
+			}
+
+			severities[name] = severity
+		}
+	}
+
+	return severities, nil
+}
+
+// decodeLintSource decodes the source, errors of the source are kept to be reported as issues.
+func decodeLintSource(content []byte) (*lintSource, error) {
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(content, &doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot decode source file")
+	}
+
+	// identifiers of generated code are checked by go-name-clash and enum-const-clash
+	type plain Source
+
+	src := new(lintSource)
+
+	src.err = yaml.Unmarshal(content, new(plain))
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return src, nil
+	}
+
+	root := doc.Content[0]
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		value := root.Content[i+1]
+
+		switch root.Content[i].Value {
+		case "app":
+			// errors are reported by decoding of Source
+			_ = value.Decode(&src.app)
+		case "lint":
+			if err := value.Decode(&src.lint); err != nil {
+				return nil, errors.Wrap(err, "cannot decode lint section")
+			}
+		case "flags":
+			src.decodeFlags(value)
+		}
+	}
+
+	return src, nil
+}
+
+func (src *lintSource) decodeFlags(node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		flag := &lintFlag{Flag: new(Flag), line: key.Line, column: key.Column}
+
+		if err := value.Decode(flag.Flag); err != nil {
+			src.flagErrs = append(src.flagErrs, &lintError{err: err, flag: flag})
+
+			continue
+		}
+
+		if flag.Name == "" {
+			flag.Name = key.Value
+		}
+
+		if err := flag.validate(); err != nil {
+			src.flagErrs = append(src.flagErrs, &lintError{err: err, flag: flag})
+		}
+
+		src.flags = append(src.flags, flag)
+	}
+}
+
+// lintReport reports the issue of the flag, flag is nil for issues of the whole source.
+type lintReport = func(flag *lintFlag, format string, args ...interface{})
+
+func checkInvalidSource(src *lintSource, report lintReport) {
+	for _, e := range src.flagErrs {
+		report(e.flag, "%s", e.err)
+	}
+
+	// the error of the source is the first error of flags if there are any
+	if src.err != nil && len(src.flagErrs) == 0 {
+		report(nil, "%s", src.err)
+	}
+}
+
+func checkDuplicateAlias(src *lintSource, report lintReport) {
+	owners := make(map[string]string)
+
+	for _, flag := range src.flags {
+		for _, alias := range flag.Aliases {
+			if owner, ok := owners[alias]; ok {
+				report(flag, "alias %q of flag %q is already declared by flag %q", alias, flag.Name, owner)
+
+				continue
+			}
+
+			owners[alias] = flag.Name
+		}
+	}
+}
+
+func checkAliasClash(src *lintSource, report lintReport) {
+	names := make(map[string]bool, len(src.flags))
+
+	for _, flag := range src.flags {
+		names[flag.Name] = true
+	}
+
+	for _, flag := range src.flags {
+		for _, alias := range flag.Aliases {
+			if names[alias] {
+				report(flag, "alias %q of flag %q is the name of a flag", alias, flag.Name)
+			}
+		}
+	}
+}
+
+// builtinFlag is the owner of names of the built-in --env flag.
+const builtinFlag = "the built-in --env flag"
+
+func checkGoNameClash(src *lintSource, report lintReport) {
+	src.checkSymbols(func(flag *lintFlag, variant string, err error) {
+		if variant == "" {
+			report(flag, "%s", err)
+		}
+	})
+}
+
+// checkSymbols declares identifiers of generated code of flags, flag is nil for environments,
+// identifiers of the runtime package are reserved as the source may be generated for any target lib.
+func (src *lintSource) checkSymbols(report func(flag *lintFlag, variant string, err error)) {
+	flags := make([]*Flag, len(src.flags))
+	lintFlags := make(map[*Flag]*lintFlag, len(src.flags))
+
+	for i, flag := range src.flags {
+		flags[i], lintFlags[flag.Flag] = flag.Flag, flag
+	}
+
+	declareSymbols(&src.app, flags, true, func(flag *Flag, variant string, err error) {
+		report(lintFlags[flag], variant, err)
+	})
+}
+
+func checkEnvVarClash(src *lintSource, report lintReport) {
+	prefix := src.app.EnvVarPrefix()
+	owners := map[string]string{src.app.EnvKey(): builtinFlag}
+
+	for _, flag := range src.flags {
+		names, ok := flag.lintEnvVars(prefix)
+		if !ok {
+			continue
+		}
+
+		owner := fmt.Sprintf("flag %q", flag.Name)
+
+		for _, name := range names {
+			if other, ok := owners[name]; ok && other != owner {
+				report(flag, "environment variable %s of flag %q is already read by %s", name, flag.Name, other)
+
+				continue
+			}
+
+			owners[name] = owner
+		}
+	}
+}
+
+// lintEnvVars returns environment variables of the flag, it's false if env attributes are invalid.
+func (flag *lintFlag) lintEnvVars(prefix string) (names []string, ok bool) {
+	defer func() {
+		// invalid env attributes are reported by invalid-source
+		if recover() != nil {
+			names, ok = nil, false
+		}
+	}()
+
+	return flag.EnvVars(prefix), true
+}
+
+func checkEnumConstClash(src *lintSource, report lintReport) {
+	src.checkSymbols(func(flag *lintFlag, variant string, err error) {
+		if variant != "" {
+			report(flag, "%s", err)
+		}
+	})
+}
+
+func checkMissingDesc(src *lintSource, report lintReport) {
+	for _, flag := range src.flags {
+		if strings.TrimSpace(flag.Desc) == "" {
+			report(flag, "flag %q has no description", flag.Name)
+		}
+	}
+}
+
+func checkRequiredDefault(src *lintSource, report lintReport) {
+	for _, flag := range src.flags {
+		if flag.Required && (flag.Value != nil || flag.Values != nil) {
+			report(flag, "required flag %q has a default value", flag.Name)
+		}
+	}
+}
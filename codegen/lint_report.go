@@ -0,0 +1,139 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// writeLintReport writes issues in the format.
+func writeLintReport(w io.Writer, format, sourceFile string, issues []*Issue) error {
+	switch format {
+	case LintFormatText, "":
+		for _, issue := range issues {
+			if _, err := fmt.Fprintln(w, issue); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case LintFormatJSON:
+		if issues == nil {
+			issues = []*Issue{}
+		}
+
+		return writeJSON(w, issues)
+	case LintFormatSARIF:
+		return writeJSON(w, sarifReport(sourceFile, issues))
+	default:
+		return errors.Errorf("unsupported lint format %q, expected text, json or sarif", format)
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
+
+// sarifLog is the subset of SARIF 2.1.0 log used by code scanning services.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func sarifReport(sourceFile string, issues []*Issue) *sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "cli-config-gen",
+			InformationURI: "https://github.com/partyzanex/cli-config-gen",
+		}},
+		Results: make([]sarifResult, 0, len(issues)),
+	}
+
+	for _, rule := range lintRules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:                   rule.name,
+			ShortDescription:     sarifMessage{Text: rule.desc},
+			DefaultConfiguration: sarifConfiguration{Level: string(rule.severity)},
+		})
+	}
+
+	for _, issue := range issues {
+		location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: sourceFile}}
+		if issue.Line > 0 {
+			location.Region = &sarifRegion{StartLine: issue.Line, StartColumn: issue.Column}
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    issue.Rule,
+			Level:     string(issue.Severity),
+			Message:   sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	return &sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	}
+}
@@ -0,0 +1,89 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint_Issues(t *testing.T) {
+	l := &Lint{SourceFile: filepath.Join("testdata", "lint", "issues.yaml")}
+
+	issues, err := l.Issues()
+	assert.NoError(t, err)
+
+	got := make([]string, len(issues))
+	for i, issue := range issues {
+		got[i] = fmt.Sprintf("%d %s %s", issue.Line, issue.Severity, issue.Rule)
+	}
+
+	assert.Equal(t, []string{
+		"14 error duplicate-alias",
+		"14 error alias-clash",
+		"14 error go-name-clash",
+		"14 error env-var-clash",
+		"18 warning missing-desc",
+		"18 error required-default",
+		"22 error env-var-clash",
+		"26 error enum-const-clash",
+		"31 error go-name-clash",
+		"31 error env-var-clash",
+	}, got)
+}
+
+func TestLint_Issues_rules(t *testing.T) {
+	l := &Lint{
+		SourceFile: filepath.Join("testdata", "lint", "issues.yaml"),
+		Rules: map[string]Severity{
+			"invalid-source":   SeverityOff,
+			"required-default": SeverityOff,
+			"env-var-clash":    SeverityWarning,
+		},
+	}
+
+	issues, err := l.Issues()
+	assert.NoError(t, err)
+	assert.Len(t, issues, 9)
+
+	for _, issue := range issues {
+		assert.NotContains(t, []string{"invalid-source", "required-default"}, issue.Rule)
+
+		if issue.Rule == "env-var-clash" {
+			assert.Equal(t, SeverityWarning, issue.Severity)
+		}
+	}
+
+	l.Rules = map[string]Severity{"unknown": SeverityOff}
+	_, err = l.Issues()
+	assert.EqualError(t, err, `unknown lint rule "unknown"`)
+
+	l.Rules = map[string]Severity{"missing-desc": "fatal"}
+	_, err = l.Issues()
+	assert.EqualError(t, err, `invalid severity "fatal" of lint rule "missing-desc", expected off, warning or error`)
+}
+
+func TestLint_Run(t *testing.T) {
+	for _, format := range []string{LintFormatText, LintFormatJSON, LintFormatSARIF} {
+		t.Run(format, func(t *testing.T) {
+			l := &Lint{
+				SourceFile: filepath.Join("testdata", "lint", "issues.yaml"),
+				Format:     format,
+			}
+
+			var buf bytes.Buffer
+
+			assert.EqualError(t, l.Run(&buf), "9 lint issues of error severity found")
+			assertGolden(t, filepath.Join("testdata", "lint", "issues."+format), buf.Bytes())
+		})
+	}
+
+	l := &Lint{SourceFile: filepath.Join("testdata", "golden", "basic.yaml"), Format: LintFormatJSON}
+
+	var buf bytes.Buffer
+
+	assert.NoError(t, l.Run(&buf))
+	assert.Contains(t, buf.String(), `"rule": "missing-desc"`)
+}
@@ -0,0 +1,213 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLockFile is the name of the lock file next to the source config file.
+const DefaultLockFile = "config.lock.yaml"
+
+const lockHeader = "# Public names of flags of the source config, update with cli-config-gen lock --update.\n"
+
+// Lock checks that public names of flags stored in the lock file are still accepted by the source:
+// flag names, aliases, environment variables and enum variants. Names of deprecated flags may be removed,
+// other removals require the update of the lock file.
+type Lock struct {
+	// SourceFile is the path of the source config file.
+	SourceFile string
+	// LockFile is the path of the lock file, DefaultLockFile next to SourceFile by default.
+	LockFile string
+	// Update writes public names of the source to the lock file instead of the check.
+	Update bool
+}
+
+// lockFile is the YAML representation of the lock file.
+type lockFile struct {
+	Flags map[string]*lockedFlag `yaml:"flags"`
+}
+
+// lockedFlag contains public names of the flag.
+type lockedFlag struct {
+	Aliases []string `yaml:"aliases,omitempty"`
+	EnvVars []string `yaml:"envVars,omitempty"`
+	Enum    []string `yaml:"enum,omitempty"`
+	// Deprecated marks flags which names may be removed.
+	Deprecated bool `yaml:"deprecated,omitempty"`
+}
+
+// Run checks the source against the lock file and writes removed names to w,
+// it returns an error if names of flags which are not deprecated are removed.
+// If Update is set, it writes the lock file.
+func (l *Lock) Run(w io.Writer) error {
+	source, err := readSourceVersion(l.SourceFile)
+	if err != nil {
+		return err
+	}
+
+	content, err := newLockFile(source).encode()
+	if err != nil {
+		return err
+	}
+
+	if l.Update {
+		return writeFiles([]*generatedFile{{path: l.lockFile(), content: content}})
+	}
+
+	locked, lockedContent, err := l.read()
+	if err != nil {
+		return err
+	}
+
+	removed := locked.removed(source)
+
+	for _, msg := range removed {
+		if _, err := fmt.Fprintln(w, msg); err != nil {
+			return err
+		}
+	}
+
+	if len(removed) > 0 {
+		return errors.Errorf("%d public names removed, deprecate flags before removal or update %s", len(removed), l.lockFile())
+	}
+
+	if !bytes.Equal(content, lockedContent) {
+		_, err = fmt.Fprintf(w, "%s is outdated, update it with --update\n", l.lockFile())
+	}
+
+	return err
+}
+
+func (l *Lock) lockFile() string {
+	if l.LockFile != "" {
+		return l.LockFile
+	}
+
+	return filepath.Join(filepath.Dir(l.SourceFile), DefaultLockFile)
+}
+
+// read returns the decoded lock file and its content.
+func (l *Lock) read() (*lockFile, []byte, error) {
+	content, err := os.ReadFile(l.lockFile())
+	if os.IsNotExist(err) {
+		return nil, nil, errors.Errorf("lock file %s not found, write it with --update", l.lockFile())
+	}
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot read lock file")
+	}
+
+	lock := new(lockFile)
+
+	err = yaml.Unmarshal(content, lock)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "cannot decode lock file %s", l.lockFile())
+	}
+
+	return lock, content, nil
+}
+
+func (lock *lockFile) encode() ([]byte, error) {
+	node := &yaml.Node{}
+
+	err := node.Encode(lock)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot encode lock file")
+	}
+
+	flowScalarSequences(node)
+
+	var buf bytes.Buffer
+
+	buf.WriteString(lockHeader)
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	err = enc.Encode(node)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot encode lock file")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// newLockFile returns public names of flags of the source.
+func newLockFile(source *Source) *lockFile {
+	lock := &lockFile{Flags: make(map[string]*lockedFlag, len(source.Flags))}
+
+	for _, flag := range source.Flags {
+		lock.Flags[flag.Name] = &lockedFlag{
+			Aliases:    flag.Aliases,
+			EnvVars:    flag.EnvVars(source.App.EnvVarPrefix()),
+			Enum:       flag.Enum,
+			Deprecated: flag.IsDeprecated(),
+		}
+	}
+
+	return lock
+}
+
+// removed returns messages of locked names which are not accepted by flags of the source,
+// names of flags which are deprecated in the lock file or in the source are skipped.
+func (lock *lockFile) removed(source *Source) []string {
+	flags := make(map[string]*Flag, len(source.Flags))
+
+	for _, flag := range source.Flags {
+		flags[flag.Name] = flag
+
+		// old names are accepted by renamed flags
+		for _, name := range flag.RenamedFrom {
+			if _, ok := flags[name]; !ok {
+				flags[name] = flag
+			}
+		}
+	}
+
+	names := make([]string, 0, len(lock.Flags))
+	for name := range lock.Flags {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var removed []string
+
+	for _, name := range names {
+		locked, flag := lock.Flags[name], flags[name]
+
+		switch {
+		case locked.Deprecated:
+			continue
+		case flag == nil:
+			removed = append(removed, fmt.Sprintf("flag %q is removed", name))
+
+			continue
+		case flag.IsDeprecated():
+			continue
+		}
+
+		accepted := append(append([]string{flag.Name}, flag.Aliases...), flag.RenamedFrom...)
+
+		for _, alias := range missing(locked.Aliases, accepted) {
+			removed = append(removed, fmt.Sprintf("alias %q of flag %q is removed", alias, name))
+		}
+
+		for _, envVar := range missing(locked.EnvVars, flag.acceptedEnvVars(source.App.EnvVarPrefix())) {
+			removed = append(removed, fmt.Sprintf("environment variable %s of flag %q is removed", envVar, name))
+		}
+
+		for _, variant := range missing(locked.Enum, flag.Enum) {
+			removed = append(removed, fmt.Sprintf("enum variant %q of flag %q is removed", variant, name))
+		}
+	}
+
+	return removed
+}
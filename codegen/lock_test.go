@@ -0,0 +1,56 @@
+package codegen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLock_Run(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "config.yaml")
+
+	content, err := os.ReadFile(filepath.Join("testdata", "lock", "config.yaml"))
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(source, content, 0o600))
+
+	var buf bytes.Buffer
+
+	lock := &Lock{SourceFile: source}
+	assert.EqualError(t, lock.Run(&buf), "lock file "+filepath.Join(filepath.Dir(source), DefaultLockFile)+
+		" not found, write it with --update")
+
+	lock.Update = true
+	assert.NoError(t, lock.Run(&buf))
+
+	b, err := os.ReadFile(filepath.Join(filepath.Dir(source), DefaultLockFile))
+	assert.NoError(t, err)
+	assertGolden(t, filepath.Join("testdata", "lock", "config.lock.yaml"), b)
+
+	lock.Update = false
+	assert.NoError(t, lock.Run(&buf))
+	assert.Empty(t, buf.String())
+
+	// new flags and names don't fail the check
+	assert.NoError(t, os.WriteFile(source, append(content, "  debug:\n    type: bool\n"...), 0o600))
+	assert.NoError(t, lock.Run(&buf))
+	assert.Equal(t, filepath.Join(filepath.Dir(source), DefaultLockFile)+" is outdated, update it with --update\n", buf.String())
+}
+
+func TestLock_Run_removed(t *testing.T) {
+	lock := &Lock{
+		SourceFile: filepath.Join("testdata", "lock", "changed.yaml"),
+		LockFile:   filepath.Join("testdata", "lock", "config.lock.yaml"),
+	}
+
+	var buf bytes.Buffer
+
+	assert.EqualError(t, lock.Run(&buf), "4 public names removed, deprecate flags before removal or update "+lock.LockFile)
+	assert.Equal(t, `enum variant "warn" of flag "level" is removed
+alias "p" of flag "port" is removed
+environment variable HTTP_PORT of flag "port" is removed
+flag "verbose" is removed
+`, buf.String())
+}
@@ -0,0 +1,149 @@
+package codegen
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// encodeSource encodes the source as YAML with the header comment.
+func encodeSource(header string, source *Source) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("# " + header + "\n")
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	err := enc.Encode(source)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot encode source")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// flagYAML is the YAML representation of Flag, empty attributes are omitted.
+type flagYAML struct {
+	Type        FlagType               `yaml:"type"`
+	Desc        string                 `yaml:"desc,omitempty"`
+	Enum        []string               `yaml:"enum,omitempty"`
+	GoType      string                 `yaml:"goType,omitempty"`
+	Layout      string                 `yaml:"layout,omitempty"`
+	Timezone    string                 `yaml:"timezone,omitempty"`
+	Aliases     []string               `yaml:"aliases,omitempty"`
+	Env         interface{}            `yaml:"env,omitempty"`
+	EnvPrimary  *bool                  `yaml:"envPrimary,omitempty"`
+	EnvExact    []string               `yaml:"envExact,omitempty"`
+	Required    bool                   `yaml:"required,omitempty"`
+	Secret      bool                   `yaml:"secret,omitempty"`
+	Hidden      bool                   `yaml:"hidden,omitempty"`
+	TakesFile   bool                   `yaml:"takesFile,omitempty"`
+	DefaultText string                 `yaml:"defaultText,omitempty"`
+	GoName      string                 `yaml:"goName,omitempty"`
+	Deprecated  string                 `yaml:"deprecated,omitempty"`
+	RenamedFrom []string               `yaml:"renamedFrom,omitempty"`
+	RemoveAfter string                 `yaml:"removeAfter,omitempty"`
+	Value       interface{}            `yaml:"value,omitempty"`
+	Values      map[string]interface{} `yaml:"values,omitempty"`
+}
+
+// MarshalYAML encodes flags as mapping of flag names to attributes in order of flags,
+// it's decoded by UnmarshalYAML.
+func (flags Flags) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, flag := range flags {
+		value := &yaml.Node{}
+
+		err := value.Encode(&flagYAML{
+			Type:        flag.Type,
+			Desc:        flag.Desc,
+			Enum:        flag.Enum,
+			GoType:      flag.CustomType,
+			Layout:      flag.Layout,
+			Timezone:    flag.Timezone,
+			Aliases:     flag.Aliases,
+			Env:         flag.Env,
+			EnvPrimary:  flag.EnvPrimary,
+			EnvExact:    flag.EnvExact,
+			Required:    flag.Required,
+			Secret:      flag.Secret,
+			Hidden:      flag.Hidden,
+			TakesFile:   flag.TakesFile,
+			DefaultText: flag.DefaultText,
+			GoName:      flag.GoName,
+			Deprecated:  flag.Deprecated,
+			RenamedFrom: flag.RenamedFrom,
+			RemoveAfter: flag.RemoveAfter,
+			Value:       flag.Value,
+			Values:      flag.Values,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		flowScalarSequences(value)
+
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: flag.Name}, value)
+	}
+
+	return node, nil
+}
+
+// MarshalYAML encodes the environment as plain name or as mapping of the name to aliases.
+func (env *Environment) MarshalYAML() (interface{}, error) {
+	if len(env.Aliases) == 0 {
+		return env.Name.String(), nil
+	}
+
+	return map[string][]string{env.Name.String(): env.Aliases}, nil
+}
+
+// MarshalYAML encodes the app, empty attributes are omitted.
+func (app App) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{}
+
+	err := node.Encode(&struct {
+		Name          string       `yaml:"name"`
+		Desc          string       `yaml:"desc,omitempty"`
+		EnvPrefix     *string      `yaml:"envPrefix,omitempty"`
+		Env           Environments `yaml:"env"`
+		EnvIgnoreCase bool         `yaml:"envIgnoreCase,omitempty"`
+		PrintConfig   bool         `yaml:"printConfig,omitempty"`
+	}{
+		Name:          app.Name,
+		Desc:          app.Desc,
+		EnvPrefix:     app.EnvPrefix,
+		Env:           app.Env,
+		EnvIgnoreCase: app.EnvIgnoreCase,
+		PrintConfig:   app.PrintConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	flowScalarSequences(node)
+
+	return node, nil
+}
+
+// flowScalarSequences sets flow style of sequences of scalars, e.g. [ a, b ].
+func flowScalarSequences(node *yaml.Node) {
+	if node.Kind == yaml.SequenceNode {
+		flow := true
+
+		for _, child := range node.Content {
+			flow = flow && child.Kind == yaml.ScalarNode
+		}
+
+		if flow {
+			node.Style = yaml.FlowStyle
+		}
+	}
+
+	for _, child := range node.Content {
+		flowScalarSequences(child)
+	}
+}
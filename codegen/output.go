@@ -0,0 +1,326 @@
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Output maps the template to the generated file.
+type Output struct {
+	// Path of the generated file.
+	Path string `yaml:"path"`
+	// Template is the path of custom template, the built-in template of target lib is used if it's empty.
+	Template string `yaml:"template,omitempty"`
+}
+
+// ParseOutput parses the output declared as PATH[=TEMPLATE].
+func ParseOutput(s string) (Output, error) {
+	path, tpl, _ := strings.Cut(s, "=")
+	if path == "" {
+		return Output{}, errors.Errorf("invalid output %q, expected PATH[=TEMPLATE]", s)
+	}
+
+	return Output{Path: path, Template: tpl}, nil
+}
+
+// outputs returns outputs of Codegen, outputs of the source are resolved relative to the source file.
+func (g *Codegen) outputs() ([]Output, error) {
+	if len(g.Outputs) > 0 {
+		return g.Outputs, nil
+	}
+
+	if len(g.source.Outputs) == 0 {
+		return []Output{{Path: g.TargetPath, Template: g.TemplatePath}}, nil
+	}
+
+	dir := filepath.Dir(g.SourceFile)
+	outputs := make([]Output, len(g.source.Outputs))
+
+	for i, output := range g.source.Outputs {
+		if output.Path == "" {
+			return nil, errors.Errorf("empty path of output #%d", i+1)
+		}
+
+		outputs[i].Path = resolvePath(dir, output.Path)
+		outputs[i].Template = resolvePath(dir, output.Template)
+	}
+
+	return outputs, nil
+}
+
+func resolvePath(dir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(dir, path)
+}
+
+type generatedFile struct {
+	path    string
+	content []byte
+}
+
+// writeFiles writes all files to temporary files first and then renames them,
+// targets which are replaced before a failed rename are restored from backups.
+func writeFiles(files []*generatedFile) error {
+	temps := make([]string, 0, len(files))
+
+	cleanup := func() {
+		for _, temp := range temps {
+			_ = os.Remove(temp)
+		}
+	}
+
+	for _, file := range files {
+		temp, err := writeTemp(file)
+		if err != nil {
+			cleanup()
+
+			return err
+		}
+
+		temps = append(temps, temp)
+	}
+
+	backups := make([]string, 0, len(files))
+
+	restore := func() {
+		for i := len(backups) - 1; i >= 0; i-- {
+			if backups[i] == "" {
+				_ = os.Remove(files[i].path)
+			} else {
+				_ = os.Rename(backups[i], files[i].path)
+			}
+		}
+
+		cleanup()
+	}
+
+	for i, file := range files {
+		backup, err := backupFile(file.path)
+		if err != nil {
+			restore()
+
+			return err
+		}
+
+		backups = append(backups, backup)
+
+		err = os.Rename(temps[i], file.path)
+		if err != nil {
+			restore()
+
+			return errors.Wrapf(err, "cannot write target file %s", file.path)
+		}
+	}
+
+	for _, backup := range backups {
+		if backup != "" {
+			_ = os.Remove(backup)
+		}
+	}
+
+	return nil
+}
+
+// backupFile moves the existing file to the backup next to it,
+// it returns empty path if the file doesn't exist.
+func backupFile(path string) (string, error) {
+	if _, err := os.Lstat(path); os.IsNotExist(err) {
+		return "", nil
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.bak")
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot back up target file %s", path)
+	}
+
+	backup := f.Name()
+
+	err = f.Close()
+	if err == nil {
+		err = os.Rename(path, backup)
+	}
+
+	if err != nil {
+		_ = os.Remove(backup)
+
+		return "", errors.Wrapf(err, "cannot back up target file %s", path)
+	}
+
+	return backup, nil
+}
+
+func writeTemp(file *generatedFile) (string, error) {
+	dir := filepath.Dir(file.path)
+
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot make target path")
+	}
+
+	f, err := os.CreateTemp(dir, "."+filepath.Base(file.path)+".*")
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot create target file %s", file.path)
+	}
+
+	_, err = f.Write(file.content)
+	if err == nil {
+		err = f.Chmod(0o644)
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(f.Name())
+
+		return "", errors.Wrapf(err, "cannot write target file %s", file.path)
+	}
+
+	return f.Name(), nil
+}
+
+// stateFile returns the path of the file which lists outputs generated from the source file.
+func (g *Codegen) stateFile() string {
+	return filepath.Join(filepath.Dir(g.SourceFile), "."+filepath.Base(g.SourceFile)+".outputs")
+}
+
+// removeStaleFiles removes files generated by the previous run which are not generated anymore
+// and saves generated files with hashes of their content to the state file. Only files inside
+// the source directory which are not changed since they were generated are removed.
+func (g *Codegen) removeStaleFiles(files []*generatedFile) error {
+	var (
+		state   = g.stateFile()
+		current = make(map[string]bool, len(files))
+		entries = make([]stateEntry, 0, len(files))
+	)
+
+	dir, err := filepath.Abs(filepath.Dir(g.SourceFile))
+	if err != nil {
+		return errors.Wrap(err, "cannot resolve source path")
+	}
+
+	for _, file := range files {
+		path, err := filepath.Abs(file.path)
+		if err == nil {
+			path, err = filepath.Rel(dir, path)
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "cannot resolve path of %s", file.path)
+		}
+
+		path = filepath.ToSlash(path)
+		current[path] = true
+		entries = append(entries, stateEntry{path: path, hash: contentHash(file.content)})
+	}
+
+	previous, err := readState(state)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range previous {
+		if current[entry.path] || !filepath.IsLocal(filepath.FromSlash(entry.path)) {
+			continue
+		}
+
+		err = removeUnchanged(filepath.Join(dir, filepath.FromSlash(entry.path)), entry.hash)
+		if err != nil {
+			return errors.Wrapf(err, "cannot remove stale file %s", entry.path)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].path < entries[j].path
+	})
+
+	var content strings.Builder
+
+	for _, entry := range entries {
+		content.WriteString(entry.hash + "  " + entry.path + "\n")
+	}
+
+	return writeFiles([]*generatedFile{{
+		path:    state,
+		content: []byte(content.String()),
+	}})
+}
+
+// stateEntry is the line of the state file: SHA-256 of the generated content and the path
+// relative to the source directory, the hash is empty in lines of the state file without it.
+type stateEntry struct {
+	path string
+	hash string
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// removeUnchanged removes the file if its content has the hash, other files are kept
+// as they are edited by the user. The file is removed if the hash is unknown.
+func removeUnchanged(path, hash string) error {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if hash != "" && contentHash(content) != hash {
+		return nil
+	}
+
+	return os.Remove(path)
+}
+
+func readState(path string) ([]stateEntry, error) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read %s", path)
+	}
+
+	var entries []stateEntry
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		entry := stateEntry{path: line}
+
+		if hash, path, ok := strings.Cut(line, "  "); ok && hashRe.MatchString(hash) {
+			entry = stateEntry{path: path, hash: hash}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// hashRe matches hashes of the state file.
+var hashRe = regexp.MustCompile(`^[0-9a-f]{64}$`)
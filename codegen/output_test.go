@@ -0,0 +1,138 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodegen_Run_outputs(t *testing.T) {
+	dir := t.TempDir()
+
+	source := filepath.Join(dir, "config.yaml")
+	writeFile := func(name, content string) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	writeFile("names.tpl", "// Code generated by test. DO NOT EDIT.\n\npackage {{.PackageName}}\n\n// {{range .Flags}}{{.Name}} {{end}}\n")
+	writeFile("config.yaml", `
+app: { name: app, env: [ local ] }
+flags:
+  port: { type: int, value: 80 }
+outputs:
+  - path: gen/config.go
+  - path: gen/names.go
+    template: names.tpl
+`)
+
+	gen := &Codegen{SourceFile: source, PackageName: "config"}
+	assert.NoError(t, gen.Run())
+
+	names, err := os.ReadFile(filepath.Join(dir, "gen/names.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "// Code generated by test. DO NOT EDIT.\n\npackage config\n\n// port \n", string(names))
+	assert.FileExists(t, filepath.Join(dir, "gen/config.go"))
+
+	config, err := os.ReadFile(filepath.Join(dir, "gen/config.go"))
+	assert.NoError(t, err)
+
+	state, err := os.ReadFile(filepath.Join(dir, ".config.yaml.outputs"))
+	assert.NoError(t, err)
+	assert.Equal(t, contentHash(config)+"  gen/config.go\n"+contentHash(names)+"  gen/names.go\n", string(state))
+
+	writeFile("config.yaml", `
+app: { name: app, env: [ local ] }
+flags:
+  port: { type: int, value: 80 }
+outputs:
+  - path: gen/config.go
+  - path: gen/unknown.go
+    template: unknown.tpl
+`)
+	assert.Error(t, gen.Run())
+	assert.FileExists(t, filepath.Join(dir, "gen/names.go"))
+
+	writeFile("config.yaml", `
+app: { name: app, env: [ local ] }
+flags:
+  port: { type: int, value: 80 }
+outputs:
+  - path: gen/config.go
+`)
+	assert.NoError(t, gen.Run())
+	assert.NoFileExists(t, filepath.Join(dir, "gen/names.go"))
+	assert.FileExists(t, filepath.Join(dir, "gen/config.go"))
+
+	// outputs of any format are removed unless they are changed after generation
+	writeFile("names.tpl", "{{range .Flags}}{{.Name}} {{end}}\n")
+	writeFile("config.yaml", `
+app: { name: app, env: [ local ] }
+flags:
+  port: { type: int, value: 80 }
+outputs:
+  - path: gen/config.go
+  - path: docs/flags.md
+    template: names.tpl
+  - path: docs/edited.md
+    template: names.tpl
+`)
+	assert.NoError(t, gen.Run())
+	writeFile("docs/edited.md", "port: HTTP port\n")
+
+	writeFile("config.yaml", `
+app: { name: app, env: [ local ] }
+flags:
+  port: { type: int, value: 80 }
+outputs:
+  - path: gen/config.go
+`)
+	assert.NoError(t, gen.Run())
+	assert.NoFileExists(t, filepath.Join(dir, "docs/flags.md"))
+	assert.FileExists(t, filepath.Join(dir, "docs/edited.md"))
+
+	// files outside the source directory are kept, files without hashes are trusted to be generated
+	outside := filepath.Join(filepath.Dir(dir), filepath.Base(dir)+"-outside.go")
+	assert.NoError(t, os.WriteFile(outside, []byte("// Code generated by test. DO NOT EDIT.\n"), 0o644))
+
+	t.Cleanup(func() {
+		_ = os.Remove(outside)
+	})
+
+	writeFile("legacy.env", "APP_PORT=80\n")
+	writeFile(".config.yaml.outputs", "../"+filepath.Base(outside)+"\nlegacy.env\ngen/config.go\n")
+
+	assert.NoError(t, gen.Run())
+	assert.FileExists(t, outside)
+	assert.NoFileExists(t, filepath.Join(dir, "legacy.env"))
+}
+
+func TestWriteFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	first := filepath.Join(dir, "first.go")
+	assert.NoError(t, os.WriteFile(first, []byte("old"), 0o644))
+
+	// the second target is a directory, so its rename fails after the first target is replaced
+	second := filepath.Join(dir, "second")
+	assert.NoError(t, os.MkdirAll(filepath.Join(second, "file"), os.ModePerm))
+
+	third := filepath.Join(dir, "third.go")
+
+	err := writeFiles([]*generatedFile{
+		{path: third, content: []byte("new")},
+		{path: first, content: []byte("new")},
+		{path: second, content: []byte("new")},
+	})
+	assert.Error(t, err)
+
+	content, err := os.ReadFile(first)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(content))
+	assert.NoFileExists(t, third)
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
@@ -0,0 +1,121 @@
+package codegen
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// jsonSchema is the part of config.schema.json checked against types of the source.
+type jsonSchema struct {
+	Properties    map[string]*jsonSchema `json:"properties"`
+	PropertyNames *jsonSchema            `json:"propertyNames"`
+	Additional    json.RawMessage        `json:"additionalProperties"`
+	Enum          []string               `json:"enum"`
+	Defs          map[string]*jsonSchema `json:"$defs"`
+}
+
+func TestSchema(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("..", "config.schema.json"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	schema := new(jsonSchema)
+	if !assert.NoError(t, json.Unmarshal(content, schema)) {
+		return
+	}
+
+	for typ, def := range map[reflect.Type]*jsonSchema{
+		reflect.TypeOf(Source{}):     schema,
+		reflect.TypeOf(App{}):        schema.Defs["app"],
+		reflect.TypeOf(Flag{}):       schema.Defs["flag"],
+		reflect.TypeOf(Output{}):     schema.Defs["output"],
+		reflect.TypeOf(LintConfig{}): schema.Defs["lint"],
+	} {
+		assert.Equal(t, yamlFields(typ), sortedKeys(def.Properties), typ.Name())
+	}
+
+	assert.Equal(t, flagTypes(t), schema.Defs["flag"].Properties["type"].Enum)
+
+	var rules []string
+	for _, rule := range lintRules {
+		rules = append(rules, rule.name)
+	}
+
+	sort.Strings(rules)
+
+	lintRulesSchema := schema.Defs["lint"].Properties["rules"]
+	assert.Equal(t, rules, lintRulesSchema.PropertyNames.Enum)
+	assert.JSONEq(t, `{"enum": ["off", "warning", "error"]}`, string(lintRulesSchema.Additional))
+}
+
+// yamlFields returns sorted YAML names of fields of the struct type.
+func yamlFields(typ reflect.Type) []string {
+	var names []string
+
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("yaml"), ",")
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func sortedKeys(m map[string]*jsonSchema) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// flagTypes returns sorted values of FlagType constants declared in flag.go.
+func flagTypes(t *testing.T) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), "flag.go", nil, 0)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+
+	var types []string
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			if ident, ok := value.Type.(*ast.Ident); !ok || ident.Name != "FlagType" {
+				continue
+			}
+
+			s, err := strconv.Unquote(value.Values[0].(*ast.BasicLit).Value)
+			assert.NoError(t, err)
+
+			types = append(types, s)
+		}
+	}
+
+	sort.Strings(types)
+
+	return types
+}
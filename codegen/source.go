@@ -0,0 +1,444 @@
+package codegen
+
+import (
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+	config "github.com/partyzanex/cli-config-gen"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+type Source struct {
+	App   App   `yaml:"app"`
+	Flags Flags `yaml:"flags"`
+	// Outputs is the manifest of generated files, paths are relative to the source file.
+	Outputs []Output `yaml:"outputs,omitempty"`
+	// Lint configures rules of the lint command.
+	Lint *LintConfig `yaml:"lint,omitempty"`
+}
+
+type App struct {
+	Name          string       `yaml:"name"`
+	Desc          string       `yaml:"desc"`
+	Env           Environments `yaml:"env"`
+	EnvIgnoreCase bool         `yaml:"envIgnoreCase"`
+	PrintConfig   bool         `yaml:"printConfig"`
+	// EnvPrefix is the prefix of environment variables, SCREAMING_SNAKE_CASE of Name by default,
+	// empty prefix disables it.
+	EnvPrefix *string `yaml:"envPrefix"`
+}
+
+// UnmarshalYAML decodes the app and checks that its names can be used in generated code.
+func (app *App) UnmarshalYAML(node *yaml.Node) error {
+	type plain App
+
+	if err := node.Decode((*plain)(app)); err != nil {
+		return err
+	}
+
+	if prefix := app.EnvVarPrefix(); prefix != "" && !envVarRe.MatchString(prefix) {
+		return errors.Errorf("invalid prefix %q of environment variables, set app.envPrefix", prefix)
+	}
+
+	return nil
+}
+
+var envVarRe = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+
+// EnvVarPrefix returns the prefix of automatic environment variables including trailing underscore.
+func (app *App) EnvVarPrefix() string {
+	prefix := strcase.ToScreamingSnake(app.Name)
+	if app.EnvPrefix != nil {
+		prefix = strings.TrimSuffix(*app.EnvPrefix, "_")
+	}
+
+	if prefix == "" {
+		return ""
+	}
+
+	return prefix + "_"
+}
+
+// EnvKey returns the environment variable of environment name, e.g. APP_ENV.
+func (app *App) EnvKey() string {
+	return app.EnvVarPrefix() + "ENV"
+}
+
+// Environment is the environment name with optional aliases.
+// In YAML it's declared as a plain name or as a single key mapping
+// of name to the list of aliases, e.g. `prod: [production, prd]`.
+type Environment struct {
+	Name    config.EnvName
+	Aliases []string
+}
+
+func (env *Environment) String() string {
+	return env.Name.String()
+}
+
+func (env *Environment) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		env.Name = config.EnvName(node.Value)
+	case yaml.MappingNode:
+		if len(node.Content) != 2 {
+			return errors.Errorf("environment should have single name, line %d", node.Line)
+		}
+
+		env.Name = config.EnvName(node.Content[0].Value)
+
+		aliases := node.Content[1]
+
+		switch aliases.Kind {
+		case yaml.ScalarNode:
+			env.Aliases = []string{aliases.Value}
+		case yaml.SequenceNode:
+			err := aliases.Decode(&env.Aliases)
+			if err != nil {
+				return errors.Wrapf(err, "cannot decode aliases of environment %q", env.Name)
+			}
+		default:
+			return errors.Errorf("unsupported aliases node kind: %d", aliases.Kind)
+		}
+	default:
+		return errors.Errorf("unsupported environment node kind: %d", node.Kind)
+	}
+
+	if env.Name == "" {
+		return errors.Errorf("empty environment name, line %d", node.Line)
+	}
+
+	if !isGoName("env-" + env.Name.String()) {
+		return errors.Errorf("invalid environment name %q, line %d", env.Name, node.Line)
+	}
+
+	return nil
+}
+
+var nameRe = regexp.MustCompile(`^\w[\w.\-]*$`)
+
+// isGoName reports whether the name consists of word characters, dots and dashes
+// and is converted to Go identifier by toCamel, names are used in identifiers and string literals of generated code.
+func isGoName(name string) bool {
+	return nameRe.MatchString(name) && token.IsIdentifier(strcase.ToCamel(name))
+}
+
+type Environments []*Environment
+
+// UnmarshalYAML decodes environments and rejects empty items which are decoded as nil.
+func (envs *Environments) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		for _, item := range node.Content {
+			if item.Tag == "!!null" {
+				return errors.Errorf("empty environment name, line %d", item.Line)
+			}
+		}
+	}
+
+	type plain Environments
+
+	return node.Decode((*plain)(envs))
+}
+
+// Names returns the list of environment names.
+func (envs Environments) Names() []config.EnvName {
+	names := make([]config.EnvName, len(envs))
+
+	for i, env := range envs {
+		names[i] = env.Name
+	}
+
+	return names
+}
+
+type Flags []*Flag
+
+func (flags *Flags) HasDateTimeFlags() bool {
+	for _, flag := range *flags {
+		switch flag.Type {
+		case FlagTypeDuration, FlagTypeTimestamp, FlagTypeDurationSlice:
+			return true
+		default: // nothing
+		}
+	}
+
+	return false
+}
+
+func (flags *Flags) HasNetFlags() bool {
+	for _, flag := range *flags {
+		if flag.IsNet() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (flags *Flags) HasTimezoneDB() bool {
+	for _, flag := range *flags {
+		if flag.HasTimezoneDB() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (flags *Flags) HasURLFlags() bool {
+	for _, flag := range *flags {
+		if flag.Type == FlagTypeURL {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (flags *Flags) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return errors.Errorf("unsupported node kind: %d", node.Kind)
+	}
+
+	results := make([]*Flag, 0, len(node.Content))
+
+	var key string
+
+	for _, content := range node.Content {
+		if content.Kind == yaml.ScalarNode {
+			key = content.Value
+		}
+
+		if content.Kind == yaml.MappingNode {
+			flag := new(Flag)
+
+			err := content.Decode(flag)
+			if err != nil {
+				return errors.Wrap(err, "Decode")
+			}
+
+			if flag.Name == "" {
+				flag.Name = key
+			}
+
+			results = append(results, flag)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	*flags = results
+
+	if err := flags.validate(); err != nil {
+		return err
+	}
+
+	return flags.resolveCustomTypes()
+}
+
+// HasDeprecatedFlags reports whether any flag is deprecated or renamed.
+func (flags *Flags) HasDeprecatedFlags() bool {
+	for _, flag := range *flags {
+		if flag.IsDeprecated() || len(flag.RenamedFrom) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasHiddenFlags reports whether any flag is hidden or renamed, old names of renamed flags are hidden in help.
+func (flags *Flags) HasHiddenFlags() bool {
+	for _, flag := range *flags {
+		if flag.Hidden || len(flag.RenamedFrom) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasRenamedFlags reports whether any flag has old names.
+func (flags *Flags) HasRenamedFlags() bool {
+	for _, flag := range *flags {
+		if len(flag.RenamedFrom) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validate checks attributes of flags and that old flag names don't clash with names and aliases of other flags.
+func (flags *Flags) validate() error {
+	names := make(map[string]string)
+
+	for _, flag := range *flags {
+		if err := flag.validate(); err != nil {
+			return err
+		}
+
+		for _, name := range append([]string{flag.Name}, flag.Aliases...) {
+			names[name] = flag.Name
+		}
+	}
+
+	for _, flag := range *flags {
+		for _, old := range flag.RenamedFrom {
+			if owner, ok := names[old]; ok {
+				return errors.Errorf("old name %q of flag %q is already used by flag %q", old, flag.Name, owner)
+			}
+
+			names[old] = flag.Name
+		}
+	}
+
+	return nil
+}
+
+// checkAliases checks that aliases are unique and don't clash with names of flags,
+// pflag normalizes aliases and old names to flag names by the single map.
+func (flags Flags) checkAliases() error {
+	names := make(map[string]bool, len(flags))
+
+	for _, flag := range flags {
+		names[flag.Name] = true
+	}
+
+	owners := make(map[string]string)
+
+	for _, flag := range flags {
+		for _, alias := range flag.Aliases {
+			if names[alias] {
+				return errors.Errorf("alias %q of flag %q is the name of a flag", alias, flag.Name)
+			}
+
+			if owner, ok := owners[alias]; ok && owner != flag.Name {
+				return errors.Errorf("alias %q of flag %q is already declared by flag %q", alias, flag.Name, owner)
+			}
+
+			owners[alias] = flag.Name
+		}
+	}
+
+	return nil
+}
+
+// Import is the import of custom flag type package.
+type Import struct {
+	Alias string
+	Path  string
+}
+
+func (imp *Import) String() string {
+	return imp.Alias + " " + strconv.Quote(imp.Path)
+}
+
+// reservedAliases contains package names used by built-in templates and generated tests,
+// including conditionally imported ones.
+var reservedAliases = map[string]bool{
+	"cli": true, "cliv3": true, "context": true, "encoding": true, "errors": true, "flag": true,
+	"fmt": true, "io": true, "log": true, "net": true, "netip": true, "os": true, "pflag": true,
+	"pflagcfg": true, "reflect": true, "sort": true, "strconv": true, "strings": true, "testing": true,
+	"time": true, "url": true,
+}
+
+// Imports returns imports of custom flag types.
+func (flags *Flags) Imports() []*Import {
+	var imports []*Import
+
+	seen := make(map[string]bool)
+
+	for _, flag := range *flags {
+		if flag.customImport != nil && !seen[flag.customImport.Path] {
+			seen[flag.customImport.Path] = true
+			imports = append(imports, flag.customImport)
+		}
+	}
+
+	sort.Slice(imports, func(i, j int) bool {
+		return imports[i].Path < imports[j].Path
+	})
+
+	return imports
+}
+
+func (flags *Flags) HasCustomFlags() bool {
+	return len(flags.Imports()) > 0
+}
+
+var customTypeRe = regexp.MustCompile(`^([\w.\-~/]+)\.([A-Z]\w*)$`)
+
+func (flags *Flags) resolveCustomTypes() error {
+	var (
+		byPath  = make(map[string]*Import)
+		aliases = make(map[string]bool)
+	)
+
+	for _, flag := range *flags {
+		if flag.Type != FlagTypeCustom {
+			continue
+		}
+
+		m := customTypeRe.FindStringSubmatch(flag.CustomType)
+		if m == nil {
+			return errors.Errorf("invalid goType %q of flag %q, expected import/path.Type", flag.CustomType, flag.Name)
+		}
+
+		if err := flag.validateCustomValue(); err != nil {
+			return err
+		}
+
+		imp, ok := byPath[m[1]]
+		if !ok {
+			imp = &Import{Alias: importAlias(m[1], aliases), Path: m[1]}
+			byPath[m[1]] = imp
+			aliases[imp.Alias] = true
+		}
+
+		flag.customImport = imp
+	}
+
+	return nil
+}
+
+// importAlias returns unique alias of the package based on the last path element,
+// major version suffix like v2 is skipped.
+func importAlias(path string, used map[string]bool) string {
+	elems := strings.Split(path, "/")
+
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && versionSuffixRe.MatchString(name) {
+		name = elems[len(elems)-2]
+	}
+
+	name = strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+
+		return -1
+	}, strings.TrimPrefix(name, "go-"))
+
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "pkg" + name
+	}
+
+	alias := name
+
+	for i := 2; used[alias] || reservedAliases[alias]; i++ {
+		alias = name + strconv.Itoa(i)
+	}
+
+	return alias
+}
+
+var versionSuffixRe = regexp.MustCompile(`^v\d+$`)
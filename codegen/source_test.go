@@ -0,0 +1,116 @@
+package codegen
+
+import (
+	"testing"
+
+	config "github.com/partyzanex/cli-config-gen"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFlags_Imports(t *testing.T) {
+	var flags Flags
+
+	err := yaml.Unmarshal([]byte(`
+level:
+  type: custom
+  goType: log/slog.Level
+region:
+  type: custom
+  goType: github.com/acme/geo/v2.Region
+zone:
+  type: custom
+  goType: github.com/acme/time.Zone
+logger:
+  type: custom
+  goType: github.com/acme/log.Logger
+`), &flags)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*Import{
+		{Alias: "geo", Path: "github.com/acme/geo/v2"},
+		{Alias: "log2", Path: "github.com/acme/log"},
+		{Alias: "time2", Path: "github.com/acme/time"},
+		{Alias: "slog", Path: "log/slog"},
+	}, flags.Imports())
+	assert.Equal(t, "log2.Logger", flags[1].GoType())
+	assert.Equal(t, "time2.Zone", flags[3].GoType())
+
+	for _, src := range []string{
+		"level: { type: custom, goType: Level }",
+		"level: { type: custom, goType: log/slog.level }",
+		"level: { type: custom, goType: log/slog.Level, value: 1 }",
+	} {
+		assert.Error(t, yaml.Unmarshal([]byte(src), &flags), src)
+	}
+}
+
+func TestFlags_validate(t *testing.T) {
+	var flags Flags
+
+	err := yaml.Unmarshal([]byte(`
+workers:
+  type: int
+  renamedFrom: [threads]
+  removeAfter: 2027-01-01
+retries:
+  type: int
+  deprecated: use --backoff
+`), &flags)
+	assert.NoError(t, err)
+	assert.True(t, flags.HasDeprecatedFlags())
+	assert.Equal(t, "Deprecated: use --backoff.", flags[0].DeprecationDoc())
+	assert.Equal(t, "Renamed from --threads, old names will be removed after 2027-01-01.", flags[1].DeprecationDoc())
+	assert.Equal(t, `[]string{"APP_WORKERS", "APP_THREADS"}`, flags[1].EnvVarsWithRenamedField("APP_"))
+
+	for _, src := range []string{
+		"workers: { type: int, renamedFrom: [retries] }\nretries: { type: int }",
+		"workers: { type: int, renamedFrom: [w] }\nretries: { type: int, aliases: [w] }",
+		"workers: { type: int, removeAfter: 2027-01-01 }",
+		"workers: { type: int, deprecated: use --threads, removeAfter: 01.01.2027 }",
+		"workers: { type: int, takesFile: true }",
+	} {
+		assert.Error(t, yaml.Unmarshal([]byte(src), &flags), src)
+	}
+}
+
+func TestApp_EnvVarPrefix(t *testing.T) {
+	var src Source
+
+	err := yaml.Unmarshal([]byte(`
+app:
+  name: simple-app
+  env: [ local ]
+flags:
+  database-url:
+    type: string
+    envPrimary: false
+    envExact: [DATABASE_URL]
+  port:
+    type: int
+    env: http-port
+`), &src)
+	assert.NoError(t, err)
+
+	prefix := src.App.EnvVarPrefix()
+	assert.Equal(t, "SIMPLE_APP_", prefix)
+	assert.Equal(t, "SIMPLE_APP_ENV", src.App.EnvKey())
+	assert.Equal(t, `[]string{"DATABASE_URL"}`, src.Flags[0].EnvVarsField(prefix))
+	assert.Equal(t, `[]string{"SIMPLE_APP_PORT", "HTTP_PORT"}`, src.Flags[1].EnvVarsField(prefix))
+
+	for envPrefix, expected := range map[string]string{"": "", "LEGACY_": "LEGACY_", "LEGACY": "LEGACY_"} {
+		src.App.EnvPrefix = &envPrefix
+		assert.Equal(t, expected, src.App.EnvVarPrefix())
+		assert.Equal(t, expected+"ENV", src.App.EnvKey())
+	}
+}
+
+func TestEnvironments_UnmarshalYAML(t *testing.T) {
+	var app App
+
+	err := yaml.Unmarshal([]byte("env: [local, {prod: [production, prd]}, {stg: staging}]"), &app)
+	assert.NoError(t, err)
+	assert.Equal(t, []config.EnvName{"local", "prod", "stg"}, app.Env.Names())
+	assert.Equal(t, []string{"production", "prd"}, app.Env[1].Aliases)
+	assert.Equal(t, []string{"staging"}, app.Env[2].Aliases)
+}
@@ -0,0 +1,177 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+
+	"github.com/iancoleman/strcase"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// builtinNames contains exported identifiers declared by built-in templates and generated tests.
+var builtinNames = []string{
+	"AppDesc", "AppName", "ApplyFlags", "CLIFlags", "Config", "Defaults", "DeprecationLogger", "Env",
+	"EnvFlag", "EnvFlagName", "EnvName", "ParseEnvName", "PrintConfig", "PrintConfigFlag", "PrintConfigFlagName",
+	"RegisterFlags", "TestDefaults", "TestFlags_precedence", "ValidateEnv", "Values",
+}
+
+// runtimeNames contains exported identifiers of the runtime package github.com/partyzanex/cli-config-gen,
+// generated code of all target libs except flag imports it with dot.
+var runtimeNames = []string{
+	"ArgsOverrideEnv", "BytesValue", "CIDRSliceValue", "ConfigDump", "ConfigEntry", "DeprecationLogger", "EnvName",
+	"EnvResolver", "Float32Value", "FormatBytes", "GetEnvName", "HostPortValue", "IPSliceValue", "IPValue",
+	"Int32Value", "MapValue", "MustLoadLocation", "MustParseText", "MustParseURL", "NewBoolSliceValue", "NewBytesValue",
+	"NewCIDRSliceValue", "NewConfigEntry", "NewDurationSliceValue", "NewEnumSliceValue", "NewFloat32Value",
+	"NewFloat64MapValue", "NewHostPortValue", "NewIPSliceValue", "NewIPValue", "NewInt32Value", "NewInt64MapValue",
+	"NewIntMapValue", "NewSliceValue", "NewStringMapValue", "NewTextValue", "NewTimeValue", "NewURLValue",
+	"NewUint32Value", "NewUint64SliceValue", "NewUintSliceValue", "NewValue", "ParseBytes", "ParseHostPort", "ParseURL",
+	"PrintFormatJSON", "PrintFormatText", "PrintFormatYAML", "RequiredRenamed", "ResolveEnvName", "SetByArgs",
+	"SliceValue", "SourceArg", "SourceDefault", "SourceEnv", "SourceFile", "SourceOf", "TextUnmarshaler", "TextValue",
+	"TimeValue", "URLValue", "Uint32Value", "Value", "ValueOf", "WarnDeprecated", "WarnRenamed",
+}
+
+// symbolTable contains identifiers of generated code by their owners.
+type symbolTable map[string]string
+
+const (
+	builtinOwner = "built-in identifier"
+	runtimeOwner = "identifier of dot-imported runtime package"
+)
+
+// newSymbolTable returns identifiers of built-in templates, runtime reserves exported identifiers
+// of the runtime package for templates which import it with dot.
+func newSymbolTable(runtime bool) symbolTable {
+	symbols := make(symbolTable, len(builtinNames)+len(runtimeNames))
+
+	if runtime {
+		for _, name := range runtimeNames {
+			symbols[name] = runtimeOwner
+		}
+	}
+
+	for _, name := range builtinNames {
+		symbols[name] = builtinOwner
+	}
+
+	return symbols
+}
+
+// declare adds the identifier of the owner, it returns an error if the identifier is invalid or already declared.
+func (symbols symbolTable) declare(name, owner string) error {
+	if !token.IsIdentifier(name) {
+		return errors.Errorf("Go name %s of %s is not a valid identifier", name, owner)
+	}
+
+	if other, ok := symbols[name]; ok {
+		return errors.Errorf("Go name %s of %s clashes with %s", name, owner, other)
+	}
+
+	symbols[name] = owner
+
+	return nil
+}
+
+// symbols returns identifiers declared by generated code for the flag.
+func (flag *Flag) symbols() []string {
+	ident := flag.GoIdent()
+	names := []string{ident, ident + "FlagName", ident + "Flag"}
+
+	if flag.HasAccessor() {
+		names = append(names, ident+"Value")
+	}
+
+	if len(flag.RenamedFrom) > 0 {
+		names = append(names, ident+"RenamedFlags")
+	}
+
+	if flag.Type == FlagTypeEnumSlice {
+		names = append(names, flag.EnumType())
+	}
+
+	return names
+}
+
+// symbolReport reports the invalid or clashing identifier of the flag, flag is nil for environments,
+// variant is the enum variant of the constant or empty for other identifiers of the flag.
+type symbolReport = func(flag *Flag, variant string, err error)
+
+// declareSymbols declares identifiers of generated code of environments and flags in the order of generation,
+// runtime reserves exported identifiers of the runtime package.
+func declareSymbols(app *App, flags []*Flag, runtime bool, report symbolReport) {
+	symbols := newSymbolTable(runtime)
+
+	for _, env := range app.Env {
+		if err := symbols.declare("Env"+strcase.ToCamel(env.String()), fmt.Sprintf("environment %q", env)); err != nil {
+			report(nil, "", err)
+		}
+	}
+
+	for _, flag := range flags {
+		owner := fmt.Sprintf("flag %q", flag.Name)
+
+		for _, name := range flag.symbols() {
+			if err := symbols.declare(name, owner); err != nil {
+				report(flag, "", errors.Errorf("%s, set goName of the flag", err))
+
+				break
+			}
+		}
+	}
+
+	for _, flag := range flags {
+		if flag.Type != FlagTypeEnum && flag.Type != FlagTypeEnumSlice {
+			continue
+		}
+
+		consts := make(map[string]bool, len(flag.Enum))
+
+		for _, variant := range flag.Enum {
+			name := flag.GoIdent() + strcase.ToCamel(variant)
+
+			// invalid and clashing variants of the same flag are rejected by validateEnum
+			if consts[name] || !token.IsIdentifier(name) {
+				continue
+			}
+
+			consts[name] = true
+
+			owner := fmt.Sprintf("enum variant %q of flag %q", variant, flag.Name)
+			if err := symbols.declare(name, owner); err != nil {
+				report(flag, variant, errors.Errorf("%s, set goName of the flag or rename the variant", err))
+			}
+		}
+	}
+}
+
+// UnmarshalYAML decodes the source, checks that it declares environments
+// and that identifiers of generated code don't clash with each other and with built-in identifiers,
+// identifiers of the runtime package are checked by Codegen for target libs which import it with dot.
+func (source *Source) UnmarshalYAML(node *yaml.Node) error {
+	type plain Source
+
+	if err := node.Decode((*plain)(source)); err != nil {
+		return err
+	}
+
+	if len(source.App.Env) == 0 {
+		return errors.New("no environments, declare them in app.env")
+	}
+
+	return source.checkSymbols(false)
+}
+
+// checkSymbols returns the first invalid or clashing identifier of generated code.
+func (source *Source) checkSymbols(runtime bool) error {
+	var errs []error
+
+	declareSymbols(&source.App, source.Flags, runtime, func(_ *Flag, _ string, err error) {
+		errs = append(errs, err)
+	})
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	return nil
+}
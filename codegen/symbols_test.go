@@ -0,0 +1,74 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntimeNames(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "*.go"))
+	assert.NoError(t, err)
+
+	var names []string
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for name, obj := range f.Scope.Objects {
+			if ast.IsExported(name) && obj.Kind != ast.Bad {
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	want := append([]string(nil), runtimeNames...)
+	sort.Strings(want)
+
+	assert.Equal(t, want, names, "runtimeNames should contain exported identifiers of the runtime package")
+}
+
+func TestCodegen_Run_runtimeNames(t *testing.T) {
+	// names of generator APIs aren't reserved
+	for name, flags := range map[string]string{
+		"generator": "{ diff: { type: bool }, lint: { type: bool }, output: { type: string } }",
+		"runtime":   "{ diff: { type: bool }, value: { type: string } }",
+	} {
+		dir := t.TempDir()
+		source := filepath.Join(dir, "config.yaml")
+
+		assert.NoError(t, os.WriteFile(source, []byte("app: { name: app, env: [ local ] }\nflags: "+flags+"\n"), 0o644))
+
+		for lib := range targetLibTemplates {
+			gen := &Codegen{
+				SourceFile:  source,
+				TargetPath:  filepath.Join(dir, filepath.FromSlash(lib), "config.go"),
+				PackageName: "config",
+				TargetLib:   lib,
+			}
+
+			err := gen.Run()
+			if name == "generator" || lib == TargetLibFlag {
+				assert.NoError(t, err, name+" "+lib)
+			} else {
+				assert.ErrorContains(t, err, "Go name Value of flag \"value\" clashes with identifier of dot-imported runtime package", lib)
+			}
+		}
+	}
+}
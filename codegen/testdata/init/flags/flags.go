@@ -0,0 +1,32 @@
+package flags
+
+import (
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const defaultPort = 8080
+
+type Options struct {
+	Timeout time.Duration     `flag:"timeout" default:"5s" usage:"Request timeout"`
+	Level   slog.Level        `flag:"log-level" default:"info"`
+	Tags    []string          `flag:"tags" default:"a,b" env:"TAGS"`
+	Headers map[string]string `flag:"header" default:"X-A=1"`
+	URL     *url.URL          `flag:"upstream" required:"true"`
+	Skip    int
+	Ch      chan int `flag:"chan"`
+}
+
+var flags = []cli.Flag{
+	&cli.StringFlag{Name: "name", Aliases: []string{"n"}, Usage: "Service name", Value: "svc", EnvVars: []string{"SVC_NAME", "NAME"}},
+	&cli.IntFlag{Name: "port", Value: defaultPort, Required: true, EnvVars: []string{"PORT"}},
+	&cli.DurationFlag{Name: "wait", Value: 3 * time.Second},
+	&cli.StringSliceFlag{Name: "hosts", Value: cli.NewStringSlice("a", "b"), EnvVars: []string{"SVC_HOSTS"}},
+	&cli.PathFlag{Name: "config", Hidden: true},
+	&cli.Float64Flag{Name: "ratio", Value: 0.5},
+	&cli.GenericFlag{Name: "generic"},
+	&cli.StringFlag{Name: "dyn", Value: time.Now().String()},
+}
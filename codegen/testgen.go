@@ -0,0 +1,201 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// samples contains values of flags which are set by args and environment variables in generated tests,
+// the first one is set by environment variable and the second one by arg. Lists and maps are separated
+// by commas, keys of maps are the same, so the value set by arg replaces the value set by environment variable
+// whether maps are merged or not. Samples of enum, enum slice and timestamp flags depend on the flag.
+var samples = map[FlagType][2]string{
+	FlagTypeString:        {"from-env", "from-arg"},
+	FlagTypeInt:           {"7", "9"},
+	FlagTypeInt32:         {"7", "9"},
+	FlagTypeInt64:         {"7", "9"},
+	FlagTypeUInt:          {"7", "9"},
+	FlagTypeUInt32:        {"7", "9"},
+	FlagTypeUInt64:        {"7", "9"},
+	FlagTypeFloat32:       {"1.5", "2.5"},
+	FlagTypeFloat64:       {"1.5", "2.5"},
+	FlagTypeBool:          {"true", "false"},
+	FlagTypeDuration:      {"1m0s", "2m0s"},
+	FlagTypeBytes:         {"1KiB", "2MiB"},
+	FlagTypeURL:           {"https://env.example.com/api", "https://arg.example.com/api"},
+	FlagTypeHostPort:      {"env.example.com:80", "arg.example.com:443"},
+	FlagTypeIP:            {"10.0.0.1", "::1"},
+	FlagTypeStringSlice:   {"a,b", "c"},
+	FlagTypeIntSlice:      {"1,2", "3"},
+	FlagTypeInt64Slice:    {"1,2", "3"},
+	FlagTypeUIntSlice:     {"1,2", "3"},
+	FlagTypeUInt64Slice:   {"1,2", "3"},
+	FlagTypeFloat64Slice:  {"0.5,1.5", "2.5"},
+	FlagTypeBoolSlice:     {"true,false", "true"},
+	FlagTypeDurationSlice: {"1s,2s", "3s"},
+	FlagTypeIPSlice:       {"10.0.0.1,10.0.0.2", "::1"},
+	FlagTypeCIDRSlice:     {"10.0.0.0/8,192.168.0.0/16", "172.16.0.0/12"},
+	FlagTypeStringMap:     {"a=from-env", "a=from-arg"},
+	FlagTypeIntMap:        {"a=7", "a=9"},
+	FlagTypeInt64Map:      {"a=7", "a=9"},
+	FlagTypeFloat64Map:    {"a=1.5", "a=2.5"},
+}
+
+// sampleTimes contains instants of timestamp samples which are formatted by the layout of the flag.
+var sampleTimes = [2]time.Time{
+	time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+	time.Date(2025, time.February, 3, 4, 5, 6, 0, time.UTC),
+}
+
+// Sample returns the value of the flag which is set by environment variable (i = 0) or by arg (i = 1)
+// in generated tests, it's empty if the flag type has no samples, e.g. custom flags.
+func (flag *Flag) Sample(i int) string {
+	switch flag.Type {
+	case FlagTypeEnum, FlagTypeEnumSlice:
+		if len(flag.Enum) == 0 {
+			return ""
+		}
+
+		// the first and the last variants
+		return flag.Enum[i*(len(flag.Enum)-1)]
+	case FlagTypeTimestamp:
+		return sampleTimes[i].Format(flag.layout())
+	default:
+		return samples[flag.Type][i]
+	}
+}
+
+// SampleLiteral returns Go literal of the Sample for the generated package of lib.
+func (flag *Flag) SampleLiteral(lib string, i int) string {
+	sample := flag.Sample(i)
+
+	switch {
+	case flag.Type == FlagTypeDuration:
+		d, _ := time.ParseDuration(sample)
+
+		return "time.Duration(" + strconv.FormatInt(int64(d), 10) + ")"
+	case flag.IsMap():
+		values := make(map[string]interface{})
+
+		for _, pair := range strings.Split(sample, ",") {
+			key, value, _ := strings.Cut(pair, "=")
+			values[key] = value
+		}
+
+		return flag.literal(lib, values)
+	case flag.IsSlice():
+		values := make([]interface{}, 0)
+
+		for _, elem := range strings.Split(sample, ",") {
+			values = append(values, elem)
+		}
+
+		return flag.literal(lib, values)
+	default:
+		return flag.literal(lib, sample)
+	}
+}
+
+// Accessor returns the method of Value which returns the typed value of the flag, e.g. StringSliceValue,
+// it's empty for enum slice and custom flags which values are returned by ValueOf.
+func (flag *Flag) Accessor() string {
+	switch flag.Type {
+	case FlagTypeEnumSlice, FlagTypeCustom:
+		return ""
+	case FlagTypeTimestamp,
+		FlagTypeStringSlice,
+		FlagTypeIntSlice,
+		FlagTypeInt64Slice,
+		FlagTypeUIntSlice,
+		FlagTypeUInt64Slice,
+		FlagTypeFloat64Slice:
+		return flag.ValueType() + "Value"
+	default:
+		return flag.ValueType()
+	}
+}
+
+// ExpectedLiteral returns Go expression of the default value of the flag for env in generated tests,
+// it's built from the YAML value independently of GoLiteral and parsed by the generated package of lib.
+func (flag *Flag) ExpectedLiteral(lib, env string) string {
+	return flag.literal(lib, flag.defaultValue(env))
+}
+
+// literal returns Go expression of the YAML value of the flag.
+func (flag *Flag) literal(lib string, value interface{}) string {
+	if value == nil {
+		return "*new(" + flag.GoType() + ")"
+	}
+
+	var elems []string
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			elems = append(elems, fmt.Sprintf("%q: %s", key, flag.expectedElem(lib, v[key])))
+		}
+	case []interface{}:
+		for _, e := range v {
+			elems = append(elems, flag.expectedElem(lib, e))
+		}
+	default:
+		if !flag.IsSlice() {
+			return flag.expectedElem(lib, value)
+		}
+
+		elems = append(elems, flag.expectedElem(lib, value))
+	}
+
+	return fmt.Sprintf("%s{%s}", flag.GoType(), strings.Join(elems, ", "))
+}
+
+// expectedElem returns Go expression of the scalar YAML value of the flag or of its element.
+func (flag *Flag) expectedElem(lib string, value interface{}) string {
+	if dt, ok := value.(time.Time); ok {
+		value = dt.Format(flag.layout())
+	}
+
+	s, ok := scalarString(value)
+	if !ok {
+		panic(flag.errorf("ExpectedLiteral: unsupported value type %T", value))
+	}
+
+	switch flag.Type {
+	case FlagTypeString, FlagTypeStringSlice, FlagTypeStringMap, FlagTypeEnum, FlagTypeEnumSlice, FlagTypeHostPort:
+		return strconv.Quote(s)
+	case FlagTypeBool, FlagTypeBoolSlice,
+		FlagTypeIntSlice, FlagTypeInt64Slice, FlagTypeUIntSlice, FlagTypeUInt64Slice, FlagTypeFloat64Slice,
+		FlagTypeIntMap, FlagTypeInt64Map, FlagTypeFloat64Map:
+		return s
+	case FlagTypeDuration, FlagTypeDurationSlice:
+		return fmt.Sprintf("must(time.ParseDuration(%q))", s)
+	case FlagTypeTimestamp:
+		return fmt.Sprintf("must(time.ParseInLocation(%s, %q, %s))", flag.LayoutExpr(), s, flag.GoLocation())
+	case FlagTypeBytes:
+		if lib == TargetLibFlag {
+			return fmt.Sprintf("must(parseBytes(%q))", s)
+		}
+
+		return fmt.Sprintf("must(ParseBytes(%q))", s)
+	case FlagTypeURL:
+		return fmt.Sprintf("mustParseURL(%q)", s)
+	case FlagTypeIP, FlagTypeIPSlice:
+		return fmt.Sprintf("netip.MustParseAddr(%q)", s)
+	case FlagTypeCIDRSlice:
+		return fmt.Sprintf("netip.MustParsePrefix(%q)", s)
+	case FlagTypeCustom:
+		return fmt.Sprintf("mustParseText[%s](%q)", flag.GoType(), s)
+	default:
+		return fmt.Sprintf("%s(%s)", flag.GoType(), s)
+	}
+}
@@ -0,0 +1,117 @@
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlag_SampleLiteral(t *testing.T) {
+	tests := []struct {
+		flag   Flag
+		sample string
+		want   string
+	}{
+		{flag: Flag{Type: FlagTypeString}, sample: "from-arg", want: `"from-arg"`},
+		{flag: Flag{Type: FlagTypeUInt32}, sample: "9", want: "uint32(9)"},
+		{flag: Flag{Type: FlagTypeBool}, sample: "false", want: "false"},
+		{flag: Flag{Type: FlagTypeDuration}, sample: "2m0s", want: "time.Duration(120000000000)"},
+		{flag: Flag{Type: FlagTypeIntSlice}, sample: "3", want: "[]int{3}"},
+		{flag: Flag{Type: FlagTypeStringMap}, sample: "a=from-arg", want: `map[string]string{"a": "from-arg"}`},
+		{flag: Flag{Type: FlagTypeBytes}, sample: "2MiB", want: `must(ParseBytes("2MiB"))`},
+		{flag: Flag{Type: FlagTypeIP}, sample: "::1", want: `netip.MustParseAddr("::1")`},
+		{flag: Flag{Type: FlagTypeEnum, Enum: []string{"debug", "info"}}, sample: "info", want: `"info"`},
+		{
+			flag:   Flag{Type: FlagTypeTimestamp, Layout: "DateOnly"},
+			sample: "2025-02-03",
+			want:   `must(time.ParseInLocation(time.DateOnly, "2025-02-03", time.UTC))`,
+		},
+		{flag: Flag{Type: FlagTypeCustom, CustomType: "log/slog.Level"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.sample, tt.flag.Sample(1))
+
+		if tt.sample != "" {
+			assert.Equal(t, tt.want, tt.flag.SampleLiteral(TargetLibCLIv2, 1))
+		}
+	}
+}
+
+func TestFlag_ExpectedLiteral(t *testing.T) {
+	tests := []struct {
+		flag Flag
+		lib  string
+		want string
+	}{
+		{flag: Flag{Type: FlagTypeInt, Value: map[string]interface{}{"prod": 443}}, want: "int(443)"},
+		{flag: Flag{Type: FlagTypeString, Value: 80}, want: `"80"`},
+		{flag: Flag{Type: FlagTypeDuration}, want: "*new(time.Duration)"},
+		{flag: Flag{Type: FlagTypeBytes, Value: "1MiB"}, lib: TargetLibFlag, want: `must(parseBytes("1MiB"))`},
+		{flag: Flag{Type: FlagTypeBytes, Value: "1MiB"}, lib: TargetLibCLIv2, want: `must(ParseBytes("1MiB"))`},
+		{
+			flag: Flag{Type: FlagTypeTimestamp, Layout: "DateOnly", Value: "2024-01-31"},
+			want: `must(time.ParseInLocation(time.DateOnly, "2024-01-31", time.UTC))`,
+		},
+		{
+			flag: Flag{Type: FlagTypeDurationSlice, Value: []interface{}{"1s"}},
+			want: `[]time.Duration{must(time.ParseDuration("1s"))}`,
+		},
+		{
+			flag: Flag{Type: FlagTypeIntMap, Value: map[string]interface{}{"b": 2, "a": 1}},
+			want: `map[string]int{"a": 1, "b": 2}`,
+		},
+		{
+			flag: Flag{
+				Type:   FlagTypeStringMap,
+				Value:  map[string]interface{}{"a": "x"},
+				Values: map[string]interface{}{"prod": map[string]interface{}{"a": 1}},
+			},
+			want: `map[string]string{"a": "1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.flag.ExpectedLiteral(tt.lib, "prod"))
+	}
+}
+
+func TestFlag_Accessor(t *testing.T) {
+	assert.Equal(t, "Int", (&Flag{Type: FlagTypeInt}).Accessor())
+	assert.Equal(t, "String", (&Flag{Type: FlagTypeEnum}).Accessor())
+	assert.Equal(t, "StringSliceValue", (&Flag{Type: FlagTypeStringSlice}).Accessor())
+	assert.Equal(t, "TimestampValue", (&Flag{Type: FlagTypeTimestamp}).Accessor())
+	assert.Equal(t, "DurationSlice", (&Flag{Type: FlagTypeDurationSlice}).Accessor())
+	assert.Empty(t, (&Flag{Type: FlagTypeEnumSlice}).Accessor())
+}
+
+func TestCodegen_Run_tests(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "config.yaml")
+
+	assert.NoError(t, os.WriteFile(source, []byte(`
+app: { name: app, env: [ local, prod ] }
+flags:
+  port: { type: int, value: { local: 80, prod: 443 } }
+  timeout: { type: duration, value: 1s }
+`), 0o644))
+
+	for lib := range targetLibTemplates {
+		gen := &Codegen{
+			SourceFile:  source,
+			TargetPath:  filepath.Join(dir, filepath.FromSlash(lib), "config.go"),
+			PackageName: "config",
+			TargetLib:   lib,
+			Tests:       true,
+		}
+		assert.NoError(t, gen.Run(), lib)
+
+		path := filepath.Join(filepath.Dir(gen.TargetPath), testFile)
+		_, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.AllErrors)
+		assert.NoError(t, err, lib)
+	}
+}
@@ -1,6 +0,0 @@
-package config
-
-import "embed"
-
-//go:embed config.tpl
-var TemplateFS embed.FS
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"flag"
+	"log"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/urfave/cli/v2"
+)
+
+// DeprecationLogger prints warnings about usage of deprecated flags and environment variables,
+// it can be replaced to use the application logger.
+var DeprecationLogger = func(msg string) {
+	log.Println("WARNING:", msg)
+}
+
+var warned sync.Map
+
+// WarnDeprecated prints a warning once if deprecated flag with given name was used.
+func WarnDeprecated(name, msg, removeAfter string) {
+	warnOnce("flag --" + name + " is deprecated: " + msg + removal(removeAfter))
+}
+
+// WarnRenamed prints a warning once for each old name of renamed flag passed in args
+// and for each environment variable of old names which is set,
+// setByArgs reports whether the flag with given name was set by parsed args, see SetByArgs.
+func WarnRenamed(name string, renamedFrom []string, setByArgs func(name string) bool, envVars []string, removeAfter string) {
+	for _, old := range renamedFrom {
+		if setByArgs(old) {
+			warnOnce("flag --" + old + " is deprecated, use --" + name + " instead" + removal(removeAfter))
+		}
+	}
+
+	for _, env := range envVars {
+		if _, found := os.LookupEnv(env); found {
+			warnOnce("environment variable " + env + " is deprecated, flag was renamed to --" + name + removal(removeAfter))
+		}
+	}
+}
+
+// RequiredRenamed returns a hidden flag which checks that the required renamed flag is set
+// by its name or by one of old names. Old names are defined as hidden flags and cli checks
+// required flags only by their names, so the renamed flag itself is not marked as required.
+func RequiredRenamed(name string, renamedFrom ...string) cli.Flag {
+	return &requiredRenamedFlag{name: name, renamedFrom: renamedFrom}
+}
+
+type requiredRenamedFlag struct {
+	name        string
+	renamedFrom []string
+	// set is the flag set of the last Apply.
+	set *flag.FlagSet
+}
+
+func (f *requiredRenamedFlag) String() string {
+	return ""
+}
+
+// Apply defines nothing, it keeps the flag set to check old names passed in args.
+func (f *requiredRenamedFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+
+	return nil
+}
+
+func (f *requiredRenamedFlag) Names() []string {
+	return []string{f.name}
+}
+
+func (f *requiredRenamedFlag) IsSet() bool {
+	return false
+}
+
+func (f *requiredRenamedFlag) IsVisible() bool {
+	return false
+}
+
+// IsRequired reports false if one of old names was passed in args, otherwise cli checks the flag name.
+func (f *requiredRenamedFlag) IsRequired() bool {
+	required := true
+
+	if f.set != nil {
+		f.set.Visit(func(fl *flag.Flag) {
+			if slices.Contains(f.renamedFrom, fl.Name) {
+				required = false
+			}
+		})
+	}
+
+	return required
+}
+
+func warnOnce(msg string) {
+	if _, loaded := warned.LoadOrStore(msg, true); !loaded {
+		DeprecationLogger(msg)
+	}
+}
+
+func removal(removeAfter string) string {
+	if removeAfter == "" {
+		return ""
+	}
+
+	return ", it will be removed after " + removeAfter
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+func TestWarnRenamed(t *testing.T) {
+	var warnings []string
+
+	logger := DeprecationLogger
+	defer func() { DeprecationLogger = logger }()
+
+	DeprecationLogger = func(msg string) {
+		warnings = append(warnings, msg)
+	}
+
+	t.Setenv("APP_WORKER_COUNT", "4")
+
+	setByArgs := func(name string) bool {
+		return name == "threads"
+	}
+
+	for i := 0; i < 2; i++ {
+		WarnRenamed("workers", []string{"threads", "worker-count"}, setByArgs, []string{"APP_THREADS", "APP_WORKER_COUNT"}, "2027-01-01")
+		WarnDeprecated("retries", "use --backoff", "")
+	}
+
+	assert.Equal(t, []string{
+		"flag --threads is deprecated, use --workers instead, it will be removed after 2027-01-01",
+		"environment variable APP_WORKER_COUNT is deprecated, flag was renamed to --workers, it will be removed after 2027-01-01",
+		"flag --retries is deprecated: use --backoff",
+	}, warnings)
+}
+
+func TestRequiredRenamed(t *testing.T) {
+	var oldSet bool
+
+	run := func(args ...string) error {
+		app := &cli.App{
+			Flags: []cli.Flag{
+				&cli.IntFlag{Name: "workers"},
+				&cli.IntFlag{Name: "threads", Hidden: true},
+				RequiredRenamed("workers", "threads"),
+			},
+			Action: func(ctx *cli.Context) error {
+				oldSet = SetByArgs(ctx)("threads")
+
+				return nil
+			},
+		}
+
+		return app.Run(append([]string{"app"}, args...))
+	}
+
+	assert.NoError(t, run("--workers", "2"))
+	assert.False(t, oldSet)
+	assert.NoError(t, run("--threads", "2"))
+	assert.True(t, oldSet)
+	assert.EqualError(t, run(), `Required flag "workers" not set`)
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EnvResolver resolves the current environment name from the environment variable
+// or from the given string, e.g. value of --env flag.
+type EnvResolver struct {
+	// Key is the environment variable name.
+	Key string
+	// Envs is the list of known environments, the first one is the default.
+	Envs []EnvName
+	// Aliases maps alternative names to the environments.
+	Aliases map[string]EnvName
+	// IgnoreCase enables case-insensitive matching of names and aliases.
+	IgnoreCase bool
+}
+
+// Resolve returns the environment named by Key variable or the default one if variable is empty.
+// The default environment is returned along with an error if variable value is unknown.
+func (r *EnvResolver) Resolve() (EnvName, error) {
+	if len(r.Envs) == 0 {
+		return "", errors.New("required environments")
+	}
+
+	name := os.Getenv(r.Key)
+	if name == "" {
+		return r.Envs[0], nil
+	}
+
+	env, err := r.Parse(name)
+	if err != nil {
+		return r.Envs[0], errors.Wrapf(err, "invalid %s", r.Key)
+	}
+
+	return env, nil
+}
+
+// Parse returns the environment matched by name or alias.
+func (r *EnvResolver) Parse(name string) (EnvName, error) {
+	for _, env := range r.Envs {
+		if r.match(name, env.String()) {
+			return env, nil
+		}
+	}
+
+	for alias, env := range r.Aliases {
+		if r.match(name, alias) {
+			return env, nil
+		}
+	}
+
+	return "", errors.Errorf("invalid environment %q", name)
+}
+
+func (r *EnvResolver) match(name, expected string) bool {
+	if r.IgnoreCase {
+		return strings.EqualFold(name, expected)
+	}
+
+	return name == expected
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvResolver_Parse(t *testing.T) {
+	resolver := &EnvResolver{
+		Envs:    []EnvName{"local", "prod"},
+		Aliases: map[string]EnvName{"production": "prod", "prd": "prod"},
+	}
+
+	env, err := resolver.Parse("prd")
+	assert.NoError(t, err)
+	assert.Equal(t, EnvName("prod"), env)
+
+	_, err = resolver.Parse("PROD")
+	assert.Error(t, err)
+
+	resolver.IgnoreCase = true
+
+	env, err = resolver.Parse("Production")
+	assert.NoError(t, err)
+	assert.Equal(t, EnvName("prod"), env)
+
+	env, err = resolver.Parse("LOCAL")
+	assert.NoError(t, err)
+	assert.Equal(t, EnvName("local"), env)
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// Int32Value implements cli.Generic and pflag.Value for int32 flags.
+type Int32Value struct {
+	value int32
+}
+
+func NewInt32Value(value int32) *Int32Value {
+	return &Int32Value{value: value}
+}
+
+func (v *Int32Value) Set(s string) error {
+	i, err := strconv.ParseInt(s, 0, 32)
+	if err != nil {
+		return errors.Wrapf(err, "invalid int32 value %q", s)
+	}
+
+	v.value = int32(i)
+
+	return nil
+}
+
+func (v *Int32Value) String() string {
+	return strconv.FormatInt(int64(v.value), 10)
+}
+
+func (v *Int32Value) Get() interface{} {
+	return v.value
+}
+
+func (v *Int32Value) Type() string {
+	return "int32"
+}
+
+// Uint32Value implements cli.Generic and pflag.Value for uint32 flags.
+type Uint32Value struct {
+	value uint32
+}
+
+func NewUint32Value(value uint32) *Uint32Value {
+	return &Uint32Value{value: value}
+}
+
+func (v *Uint32Value) Set(s string) error {
+	u, err := strconv.ParseUint(s, 0, 32)
+	if err != nil {
+		return errors.Wrapf(err, "invalid uint32 value %q", s)
+	}
+
+	v.value = uint32(u)
+
+	return nil
+}
+
+func (v *Uint32Value) String() string {
+	return strconv.FormatUint(uint64(v.value), 10)
+}
+
+func (v *Uint32Value) Get() interface{} {
+	return v.value
+}
+
+func (v *Uint32Value) Type() string {
+	return "uint32"
+}
+
+// Float32Value implements cli.Generic and pflag.Value for float32 flags.
+type Float32Value struct {
+	value float32
+}
+
+func NewFloat32Value(value float32) *Float32Value {
+	return &Float32Value{value: value}
+}
+
+func (v *Float32Value) Set(s string) error {
+	f, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return errors.Wrapf(err, "invalid float32 value %q", s)
+	}
+
+	v.value = float32(f)
+
+	return nil
+}
+
+func (v *Float32Value) String() string {
+	return strconv.FormatFloat(float64(v.value), 'g', -1, 32)
+}
+
+func (v *Float32Value) Get() interface{} {
+	return v.value
+}
+
+func (v *Float32Value) Type() string {
+	return "float32"
+}
+
+// BytesValue implements cli.Generic and pflag.Value for human-readable sizes, see ParseBytes.
+type BytesValue struct {
+	value uint64
+}
+
+func NewBytesValue(value uint64) *BytesValue {
+	return &BytesValue{value: value}
+}
+
+func (v *BytesValue) Set(s string) error {
+	size, err := ParseBytes(s)
+	if err != nil {
+		return err
+	}
+
+	v.value = size
+
+	return nil
+}
+
+func (v *BytesValue) String() string {
+	return FormatBytes(v.value)
+}
+
+func (v *BytesValue) Get() interface{} {
+	return v.value
+}
+
+func (v *BytesValue) Type() string {
+	return "bytes"
+}
+
+// TimeValue implements cli.Generic and pflag.Value for timestamps with layout and location.
+type TimeValue struct {
+	value    time.Time
+	layout   string
+	location *time.Location
+}
+
+func NewTimeValue(value time.Time, layout string, location *time.Location) *TimeValue {
+	return &TimeValue{value: value, layout: layout, location: location}
+}
+
+func (v *TimeValue) Set(s string) error {
+	ts, err := time.ParseInLocation(v.layout, s, v.location)
+	if err != nil {
+		return errors.Wrapf(err, "invalid timestamp %q", s)
+	}
+
+	v.value = ts
+
+	return nil
+}
+
+func (v *TimeValue) String() string {
+	if v.value.IsZero() {
+		return ""
+	}
+
+	return v.value.Format(v.layout)
+}
+
+func (v *TimeValue) Get() interface{} {
+	return v.value
+}
+
+func (v *TimeValue) Type() string {
+	return "time"
+}
+
+// MustLoadLocation returns the location with given name, it panics on error.
+// Generated packages import time/tzdata so valid names are always loaded.
+func MustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(any(err))
+	}
+
+	return loc
+}
+
+// ArgsOverrideEnv makes args override the value of slice or map generic flag set from environment variables,
+// cli sets the Value from environment variables before args are parsed to it, so args would be appended.
+func ArgsOverrideEnv(flag *cli.GenericFlag) *cli.GenericFlag {
+	flag.Destination = &argsValue{Generic: flag.Value}
+
+	return flag
+}
+
+// argsValue is the Destination of generic flag which parses args to its Value,
+// the first arg resets values parsed from environment variables.
+type argsValue struct {
+	cli.Generic
+	set bool
+}
+
+func (v *argsValue) Set(s string) error {
+	if r, ok := v.Generic.(interface{ reset() }); ok && !v.set {
+		r.reset()
+	}
+
+	v.set = true
+
+	return v.Generic.Set(s)
+}
+
+func (v *argsValue) Get() interface{} {
+	if getter, ok := v.Generic.(interface{ Get() interface{} }); ok {
+		return getter.Get()
+	}
+
+	return v.Generic
+}
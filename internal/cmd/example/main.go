@@ -27,7 +27,7 @@ func action(ctx *cli.Context) error {
 }
 
 func before(ctx *cli.Context) error {
-	return nil
+	return config.ValidateEnv()
 }
 
 func after(ctx *cli.Context) error {
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MapValue implements cli.Generic and pflag.Value for key-value flags,
+// it accepts comma separated or repeated k=v pairs, e.g. --header a=1,b=2 --header c=3.
+type MapValue[T any] struct {
+	value map[string]T
+	parse func(string) (T, error)
+	typ   string
+	set   bool
+}
+
+func NewStringMapValue(value map[string]string) *MapValue[string] {
+	return &MapValue[string]{value: value, parse: parseMapString, typ: "stringMap"}
+}
+
+func NewIntMapValue(value map[string]int) *MapValue[int] {
+	return &MapValue[int]{value: value, parse: strconv.Atoi, typ: "intMap"}
+}
+
+func NewInt64MapValue(value map[string]int64) *MapValue[int64] {
+	return &MapValue[int64]{value: value, parse: parseMapInt64, typ: "int64Map"}
+}
+
+func NewFloat64MapValue(value map[string]float64) *MapValue[float64] {
+	return &MapValue[float64]{value: value, parse: parseMapFloat64, typ: "float64Map"}
+}
+
+func (v *MapValue[T]) Set(s string) error {
+	values := make(map[string]T)
+
+	for _, pair := range splitList(s) {
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return errors.Errorf("invalid key-value pair %q, expected k=v", pair)
+		}
+
+		parsed, err := v.parse(strings.TrimSpace(val))
+		if err != nil {
+			return errors.Wrapf(err, "invalid value of key %q", key)
+		}
+
+		values[strings.TrimSpace(key)] = parsed
+	}
+
+	if !v.set || v.value == nil {
+		// the first value replaces defaults
+		v.value, v.set = make(map[string]T, len(values)), true
+	}
+
+	for key, val := range values {
+		v.value[key] = val
+	}
+
+	return nil
+}
+
+// reset makes the next parsed value replace values parsed before, see ArgsOverrideEnv.
+func (v *MapValue[T]) reset() {
+	v.set = false
+}
+
+func (v *MapValue[T]) String() string {
+	keys := make([]string, 0, len(v.value))
+
+	for key := range v.value {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+
+	for i, key := range keys {
+		pairs[i] = key + "=" + stringOf(v.value[key])
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func (v *MapValue[T]) Get() interface{} {
+	return v.value
+}
+
+func (v *MapValue[T]) Type() string {
+	return v.typ
+}
+
+func parseMapString(s string) (string, error) {
+	return s, nil
+}
+
+func parseMapInt64(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func parseMapFloat64(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+func stringOf(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapValue_Set(t *testing.T) {
+	defaults := map[string]string{"X-Source": "app"}
+
+	v := NewStringMapValue(defaults)
+	assert.Equal(t, "X-Source=app", v.String())
+
+	assert.NoError(t, v.Set("b=2, a=1"))
+	assert.NoError(t, v.Set("c=x=y"))
+	assert.Equal(t, "a=1,b=2,c=x=y", v.String())
+	assert.Equal(t, map[string]string{"X-Source": "app"}, defaults)
+
+	assert.Error(t, v.Set("key"))
+	assert.Error(t, v.Set("=value"))
+
+	i := NewIntMapValue(nil)
+	assert.NoError(t, i.Set("tenant-a=10"))
+	assert.Error(t, i.Set("tenant-b=ten"))
+	assert.Equal(t, "intMap", i.Type())
+
+	value := NewValue("test").SetIntMap("test", nil)
+	value.SetGeneric("test", i)
+	assert.Equal(t, map[string]int{"tenant-a": 10}, value.IntMap())
+	assert.Equal(t, map[string]int{"tenant-a": 10}, value.Env("prod").IntMap())
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"net"
+	"net/netip"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// ParseURL parses absolute URL, e.g. https://example.com/api.
+func ParseURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid url %q", s)
+	}
+
+	if !u.IsAbs() {
+		return nil, errors.Errorf("invalid url %q: scheme is required", s)
+	}
+
+	return u, nil
+}
+
+// MustParseURL is like ParseURL but panics if s cannot be parsed.
+func MustParseURL(s string) *url.URL {
+	u, err := ParseURL(s)
+	if err != nil {
+		panic(any(err))
+	}
+
+	return u
+}
+
+// ParseHostPort validates address in host:port form, host may be empty, e.g. :8080.
+func ParseHostPort(s string) (string, error) {
+	_, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid host:port %q", s)
+	}
+
+	if _, err = strconv.ParseUint(port, 10, 16); err != nil {
+		return "", errors.Errorf("invalid host:port %q: invalid port %q", s, port)
+	}
+
+	return s, nil
+}
+
+func parseAddr(s string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(s)
+
+	return addr, errors.Wrapf(err, "invalid ip %q", s)
+}
+
+func parsePrefix(s string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(s)
+
+	return prefix, errors.Wrapf(err, "invalid cidr %q", s)
+}
+
+// URLValue implements cli.Generic and pflag.Value for url flags.
+type URLValue struct {
+	value *url.URL
+}
+
+func NewURLValue(value *url.URL) *URLValue {
+	return &URLValue{value: value}
+}
+
+func (v *URLValue) Set(s string) error {
+	u, err := ParseURL(s)
+	if err != nil {
+		return err
+	}
+
+	v.value = u
+
+	return nil
+}
+
+func (v *URLValue) String() string {
+	if v.value == nil {
+		return ""
+	}
+
+	return v.value.String()
+}
+
+func (v *URLValue) Get() interface{} {
+	return v.value
+}
+
+func (v *URLValue) Type() string {
+	return "url"
+}
+
+// HostPortValue implements cli.Generic and pflag.Value for host:port flags.
+type HostPortValue struct {
+	value string
+}
+
+func NewHostPortValue(value string) *HostPortValue {
+	return &HostPortValue{value: value}
+}
+
+func (v *HostPortValue) Set(s string) error {
+	hostPort, err := ParseHostPort(s)
+	if err != nil {
+		return err
+	}
+
+	v.value = hostPort
+
+	return nil
+}
+
+func (v *HostPortValue) String() string {
+	return v.value
+}
+
+func (v *HostPortValue) Get() interface{} {
+	return v.value
+}
+
+func (v *HostPortValue) Type() string {
+	return "hostPort"
+}
+
+// IPValue implements cli.Generic and pflag.Value for ip flags.
+type IPValue struct {
+	value netip.Addr
+}
+
+func NewIPValue(value netip.Addr) *IPValue {
+	return &IPValue{value: value}
+}
+
+func (v *IPValue) Set(s string) error {
+	addr, err := parseAddr(s)
+	if err != nil {
+		return err
+	}
+
+	v.value = addr
+
+	return nil
+}
+
+func (v *IPValue) String() string {
+	if !v.value.IsValid() {
+		return ""
+	}
+
+	return v.value.String()
+}
+
+func (v *IPValue) Get() interface{} {
+	return v.value
+}
+
+func (v *IPValue) Type() string {
+	return "ip"
+}
+
+// IPSliceValue implements cli.Generic and pflag.Value for comma separated or repeated ip flags.
+type IPSliceValue = SliceValue[netip.Addr]
+
+func NewIPSliceValue(value []netip.Addr) *IPSliceValue {
+	return NewSliceValue(value, "ipSlice", parseAddr)
+}
+
+// CIDRSliceValue implements cli.Generic and pflag.Value for comma separated or repeated cidr flags.
+type CIDRSliceValue = SliceValue[netip.Prefix]
+
+func NewCIDRSliceValue(value []netip.Prefix) *CIDRSliceValue {
+	return NewSliceValue(value, "cidrSlice", parsePrefix)
+}
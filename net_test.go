@@ -0,0 +1,57 @@
+package config
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseURL(t *testing.T) {
+	u, err := ParseURL("https://example.com:8443/api?v=1")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com:8443", u.Host)
+
+	for _, s := range []string{"", "/api", "example.com", "http://[::1"} {
+		_, err = ParseURL(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseHostPort(t *testing.T) {
+	for _, s := range []string{":8080", "localhost:80", "127.0.0.1:65535", "[::1]:443"} {
+		hostPort, err := ParseHostPort(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, s, hostPort)
+	}
+
+	for _, s := range []string{"", "localhost", "localhost:http", ":65536", "::1:80"} {
+		_, err := ParseHostPort(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestIPSliceValue_Set(t *testing.T) {
+	v := NewIPSliceValue([]netip.Addr{netip.MustParseAddr("127.0.0.1")})
+	assert.Equal(t, "127.0.0.1", v.String())
+
+	assert.NoError(t, v.Set("10.0.0.1, 10.0.0.2"))
+	assert.NoError(t, v.Set("::1"))
+	assert.Equal(t, "10.0.0.1,10.0.0.2,::1", v.String())
+	assert.Error(t, v.Set("10.0.0.256"))
+
+	value := NewValue("test").SetIPSlice("test")
+	value.SetGeneric("test", v)
+	assert.Len(t, value.IPSlice(), 3)
+}
+
+func TestCIDRSliceValue_Set(t *testing.T) {
+	v := NewCIDRSliceValue(nil)
+
+	assert.NoError(t, v.Set("10.0.0.0/8,192.168.0.0/16"))
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+	}, v.Get())
+	assert.Error(t, v.Set("10.0.0.1"))
+}
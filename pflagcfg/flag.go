@@ -0,0 +1,94 @@
+// Package pflagcfg contains runtime helpers for config packages generated for spf13/cobra and spf13/pflag.
+package pflagcfg
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+)
+
+// FilenameAnnotation is the value of cobra.BashCompFilenameExt, the flag annotated by it
+// with empty list of extensions is completed by file names.
+const FilenameAnnotation = "cobra_annotation_bash_completion_filename_extensions"
+
+// BindEnv sets the flag from the first found environment variable unless it was changed by args.
+func BindEnv(fs *pflag.FlagSet, name string, envVars ...string) error {
+	if fs.Changed(name) {
+		return nil
+	}
+
+	for _, env := range envVars {
+		value, found := os.LookupEnv(env)
+		if !found {
+			continue
+		}
+
+		err := fs.Set(name, value)
+		if err != nil {
+			return errors.Wrapf(err, "cannot set flag %q from environment variable %s", name, env)
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// CheckRequired returns an error if any of flags was not set by args or environment variables.
+func CheckRequired(fs *pflag.FlagSet, names ...string) error {
+	var missing []string
+
+	for _, name := range names {
+		if !fs.Changed(name) {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.Errorf("required flags %q not set", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// passedAliasesAnnotation annotates the flag with aliases which were normalized to the flag name.
+const passedAliasesAnnotation = "cli_config_gen_passed_aliases"
+
+// AliasNormalizer returns the normalize func which maps aliases to the flag names,
+// aliases passed in args are annotated on the flag, see SetByArgs.
+func AliasNormalizer(aliases map[string]string) func(*pflag.FlagSet, string) pflag.NormalizedName {
+	return func(fs *pflag.FlagSet, name string) pflag.NormalizedName {
+		flagName, ok := aliases[name]
+		if !ok {
+			return pflag.NormalizedName(name)
+		}
+
+		if flag := fs.Lookup(flagName); flag != nil && !slices.Contains(flag.Annotations[passedAliasesAnnotation], name) {
+			if flag.Annotations == nil {
+				flag.Annotations = make(map[string][]string)
+			}
+
+			flag.Annotations[passedAliasesAnnotation] = append(flag.Annotations[passedAliasesAnnotation], name)
+		}
+
+		return pflag.NormalizedName(flagName)
+	}
+}
+
+// SetByArgs returns the func which reports whether the alias with given name was passed in args parsed by fs,
+// the alias is looked up on the flag which it's mapped to by aliases of AliasNormalizer.
+func SetByArgs(fs *pflag.FlagSet, aliases map[string]string) func(name string) bool {
+	return func(name string) bool {
+		flagName, ok := aliases[name]
+		if !ok {
+			return false
+		}
+
+		flag := fs.Lookup(flagName)
+
+		return flag != nil && slices.Contains(flag.Annotations[passedAliasesAnnotation], name)
+	}
+}
@@ -0,0 +1,61 @@
+package pflagcfg
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindEnv(t *testing.T) {
+	err := os.Setenv("TEST_PFLAG_PORT", "8080")
+	assert.NoError(t, err)
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.String("host", "localhost", "")
+	fs.Int("port", 80, "")
+	Uint64SliceP(fs, "ids", "", []uint64{1}, "")
+	fs.SetNormalizeFunc(AliasNormalizer(map[string]string{"address": "host"}))
+
+	err = fs.Parse([]string{"--address", "example.com", "--ids", "2,3", "--ids", "4"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, BindEnv(fs, "host", "TEST_PFLAG_PORT"))
+	assert.NoError(t, BindEnv(fs, "port", "TEST_PFLAG_UNKNOWN", "TEST_PFLAG_PORT"))
+
+	host, err := fs.GetString("host")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", host)
+
+	port, err := fs.GetInt("port")
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, port)
+
+	ids, err := GetUint64Slice(fs, "ids")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{2, 3, 4}, ids)
+
+	fs.Bool("debug", false, "")
+
+	assert.NoError(t, CheckRequired(fs, "host", "port"))
+	assert.Error(t, CheckRequired(fs, "host", "debug"))
+}
+
+func TestSetByArgs(t *testing.T) {
+	aliases := map[string]string{"threads": "workers", "procs": "workers", "w": "wait"}
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.Int("workers", 1, "")
+	fs.Bool("wait", false, "")
+	fs.SetNormalizeFunc(AliasNormalizer(aliases))
+
+	assert.NoError(t, fs.Parse([]string{"--threads", "2"}))
+
+	setByArgs := SetByArgs(fs, aliases)
+	assert.True(t, setByArgs("threads"))
+	assert.False(t, setByArgs("procs"))
+	assert.False(t, setByArgs("workers"))
+	assert.False(t, setByArgs("w"))
+	assert.False(t, setByArgs("unknown"))
+}
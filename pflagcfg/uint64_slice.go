@@ -0,0 +1,79 @@
+package pflagcfg
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+)
+
+const uint64SliceType = "uint64Slice"
+
+// uint64SliceValue implements pflag.Value for []uint64, pflag has no native flag for it.
+type uint64SliceValue struct {
+	value   *[]uint64
+	changed bool
+}
+
+func (s *uint64SliceValue) Set(val string) error {
+	parts := strings.Split(val, ",")
+	out := make([]uint64, len(parts))
+
+	for i, part := range parts {
+		u, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "cannot parse %q", part)
+		}
+
+		out[i] = u
+	}
+
+	if s.changed {
+		*s.value = append(*s.value, out...)
+	} else {
+		*s.value = out
+		s.changed = true
+	}
+
+	return nil
+}
+
+func (s *uint64SliceValue) Type() string {
+	return uint64SliceType
+}
+
+func (s *uint64SliceValue) String() string {
+	out := make([]string, len(*s.value))
+
+	for i, u := range *s.value {
+		out[i] = strconv.FormatUint(u, 10)
+	}
+
+	return "[" + strings.Join(out, ",") + "]"
+}
+
+// Uint64SliceP defines a []uint64 flag with specified name, shorthand, default value, and usage string.
+func Uint64SliceP(fs *pflag.FlagSet, name, shorthand string, value []uint64, usage string) *[]uint64 {
+	p := new([]uint64)
+	*p = value
+
+	fs.VarP(&uint64SliceValue{value: p}, name, shorthand, usage)
+
+	return p
+}
+
+// GetUint64Slice returns the []uint64 value of the flag with the given name.
+func GetUint64Slice(fs *pflag.FlagSet, name string) ([]uint64, error) {
+	flag := fs.Lookup(name)
+	if flag == nil {
+		return nil, errors.Errorf("flag accessed but not defined: %s", name)
+	}
+
+	value, ok := flag.Value.(*uint64SliceValue)
+	if !ok {
+		return nil, errors.Errorf("trying to get %s value of flag of type %s", uint64SliceType, flag.Value.Type())
+	}
+
+	return *value.value, nil
+}
@@ -0,0 +1,254 @@
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Print formats.
+const (
+	PrintFormatText = "text"
+	PrintFormatJSON = "json"
+	PrintFormatYAML = "yaml"
+)
+
+// Value sources.
+const (
+	SourceDefault = "default"
+	SourceArg     = "arg"
+	SourceEnv     = "env"
+	SourceFile    = "file"
+)
+
+const redactedValue = "******"
+
+// ConfigEntry describes the resolved value of a single flag.
+type ConfigEntry struct {
+	Name   string      `json:"name" yaml:"name"`
+	Value  interface{} `json:"value" yaml:"value"`
+	Source string      `json:"source" yaml:"source"`
+	EnvVar string      `json:"envVar,omitempty" yaml:"envVar,omitempty"`
+	// DefaultEnv is the environment which default value is used.
+	DefaultEnv EnvName `json:"defaultEnv,omitempty" yaml:"defaultEnv,omitempty"`
+}
+
+// ConfigDump is the effective configuration of the application.
+type ConfigDump struct {
+	Env   EnvName       `json:"env" yaml:"env"`
+	Flags []ConfigEntry `json:"flags" yaml:"flags"`
+}
+
+// NewConfigEntry returns the entry for the flag with given name, the value should be resolved by the caller,
+// the source is looked up in ctx by the name and then by old names of renamed flag.
+func NewConfigEntry(ctx *cli.Context, name string, value interface{}, secret bool, renamedFrom ...string) ConfigEntry {
+	entry := ConfigEntry{
+		Name:  name,
+		Value: printableValue(value),
+	}
+
+	entry.Source, entry.EnvVar = lookupSource(ctx, name)
+
+	for _, old := range renamedFrom {
+		if entry.Source != SourceDefault {
+			break
+		}
+
+		entry.Source, entry.EnvVar = lookupSource(ctx, old)
+	}
+
+	if secret {
+		entry.Value = redactedValue
+	}
+
+	return entry
+}
+
+// WithDefault sets the environment of the default value of the flag if the entry has no other source.
+func (entry ConfigEntry) WithDefault(value *Value) ConfigEntry {
+	if entry.Source == SourceDefault && value != nil {
+		entry.DefaultEnv = value.DefaultEnv()
+	}
+
+	return entry
+}
+
+func (d *ConfigDump) Write(w io.Writer, format string) error {
+	switch format {
+	case PrintFormatText, "":
+		return d.writeText(w)
+	case PrintFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return errors.Wrap(enc.Encode(d), "cannot encode config to json")
+	case PrintFormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+
+		err := enc.Encode(d)
+		if err != nil {
+			return errors.Wrap(err, "cannot encode config to yaml")
+		}
+
+		return errors.Wrap(enc.Close(), "cannot encode config to yaml")
+	default:
+		return errors.Errorf("unsupported print format %q", format)
+	}
+}
+
+func (d *ConfigDump) writeText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "env:\t%s\n\n", d.Env)
+	fmt.Fprintln(tw, "FLAG\tVALUE\tSOURCE")
+
+	for _, entry := range d.Flags {
+		source := entry.Source
+		if entry.EnvVar != "" {
+			source += " (" + entry.EnvVar + ")"
+		}
+
+		if entry.DefaultEnv != "" {
+			source += " (" + entry.DefaultEnv.String() + ")"
+		}
+
+		fmt.Fprintf(tw, "%s\t%v\t%s\n", entry.Name, entry.Value, source)
+	}
+
+	return errors.Wrap(tw.Flush(), "cannot write config")
+}
+
+func printableValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Duration:
+		return v.String()
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+
+		return v.Format(time.RFC3339)
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+
+		return v.Format(time.RFC3339)
+	case *BytesValue:
+		return v.String()
+	case *url.URL:
+		if v == nil {
+			return nil
+		}
+
+		return v.String()
+	case netip.Addr:
+		return v.String()
+	case []time.Duration:
+		return toStrings(v)
+	case []netip.Addr:
+		return toStrings(v)
+	case []netip.Prefix:
+		return toStrings(v)
+	case interface{ Get() interface{} }:
+		// value of generic flag
+		return printableValue(v.Get())
+	case encoding.TextMarshaler:
+		if text, err := v.MarshalText(); err == nil {
+			return string(text)
+		}
+
+		return value
+	default:
+		return value
+	}
+}
+
+// lookupSource returns the source of the flag with given name, cli marks flags set from env or file before args
+// are parsed, so the flag which is also passed in args is reported with the environment variable or the file.
+func lookupSource(ctx *cli.Context, name string) (source, envVar string) {
+	if ctx == nil || !ctx.IsSet(name) {
+		return SourceDefault, ""
+	}
+
+	flag := lookupFlag(ctx, name)
+	if flag == nil || !flag.IsSet() {
+		return SourceArg, ""
+	}
+
+	var envVars []string
+	if df, ok := flag.(cli.DocGenerationFlag); ok {
+		envVars = df.GetEnvVars()
+	}
+
+	return SourceOf(false, envVars)
+}
+
+// SourceOf returns the source of the flag value which was set by args or by sources of the flag:
+// the first set environment variable of envVars or the file.
+func SourceOf(byArgs bool, envVars []string) (source, envVar string) {
+	if byArgs {
+		return SourceArg, ""
+	}
+
+	for _, env := range envVars {
+		env = strings.TrimSpace(env)
+
+		if _, found := os.LookupEnv(env); found {
+			return SourceEnv, env
+		}
+	}
+
+	return SourceFile, ""
+}
+
+// SetByArgs returns the func which reports whether the flag with given name was set by args parsed by ctx.
+func SetByArgs(ctx *cli.Context) func(name string) bool {
+	return func(name string) bool {
+		source, _ := lookupSource(ctx, name)
+
+		return source == SourceArg
+	}
+}
+
+func lookupFlag(ctx *cli.Context, name string) cli.Flag {
+	for _, c := range ctx.Lineage() {
+		if c.Command == nil {
+			continue
+		}
+
+		if flag := findFlag(c.Command.Flags, name); flag != nil {
+			return flag
+		}
+	}
+
+	if ctx.App != nil {
+		return findFlag(ctx.App.Flags, name)
+	}
+
+	return nil
+}
+
+func findFlag(flags []cli.Flag, name string) cli.Flag {
+	for _, flag := range flags {
+		for _, n := range flag.Names() {
+			if n == name {
+				return flag
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+func TestConfigDump_Write(t *testing.T) {
+	err := os.Setenv("TEST_PRINT_PORT", "8080")
+	assert.NoError(t, err)
+
+	var dump *ConfigDump
+
+	// cli marks flags set from env, so each run needs new flags
+	flags := func() []cli.Flag {
+		return []cli.Flag{
+			&cli.StringFlag{Name: "host", Value: "localhost"},
+			&cli.IntFlag{Name: "port", EnvVars: []string{"TEST_PRINT_PORT"}},
+			&cli.StringFlag{Name: "password"},
+		}
+	}
+
+	app := &cli.App{
+		Flags: flags(),
+		Action: func(ctx *cli.Context) error {
+			dump = &ConfigDump{
+				Env: "test",
+				Flags: []ConfigEntry{
+					NewConfigEntry(ctx, "host", ctx.String("host"), false),
+					NewConfigEntry(ctx, "port", ctx.Int("port"), false),
+					NewConfigEntry(ctx, "password", ctx.String("password"), true),
+				},
+			}
+
+			return nil
+		},
+	}
+
+	err = app.Run([]string{"app", "--password", "qwerty"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []ConfigEntry{
+		{Name: "host", Value: "localhost", Source: SourceDefault},
+		{Name: "port", Value: 8080, Source: SourceEnv, EnvVar: "TEST_PRINT_PORT"},
+		{Name: "password", Value: redactedValue, Source: SourceArg},
+	}, dump.Flags)
+
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, dump.Write(buf, PrintFormatJSON))
+	assert.Contains(t, buf.String(), `"envVar": "TEST_PRINT_PORT"`)
+	assert.NotContains(t, buf.String(), "qwerty")
+
+	buf.Reset()
+
+	assert.NoError(t, dump.Write(buf, PrintFormatYAML))
+	assert.Contains(t, buf.String(), "source: env")
+
+	assert.Error(t, dump.Write(buf, "xml"))
+
+	// args passed to app.Run take precedence over environment variables,
+	// but the source is the environment variable which is set
+	app.Flags = flags()
+	err = app.Run([]string{"app", "--port", "9090"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, ConfigEntry{Name: "port", Value: 9090, Source: SourceEnv, EnvVar: "TEST_PRINT_PORT"}, dump.Flags[1])
+
+	assert.NoError(t, os.Unsetenv("TEST_PRINT_PORT"))
+
+	app.Flags = flags()
+	err = app.Run([]string{"app", "--port", "9090"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, ConfigEntry{Name: "port", Value: 9090, Source: SourceArg}, dump.Flags[1])
+
+	// PrintConfig may be called before the app is run
+	assert.Equal(t, ConfigEntry{Name: "port", Value: 80, Source: SourceDefault}, NewConfigEntry(nil, "port", 80, false))
+}
+
+func TestConfigEntry_WithDefault(t *testing.T) {
+	value := NewValue("dev").Set("prod", "a").Set("test", "b")
+
+	entry := ConfigEntry{Name: "host", Value: "a", Source: SourceDefault}.WithDefault(value)
+	assert.Equal(t, EnvName("prod"), entry.DefaultEnv)
+
+	entry = ConfigEntry{Name: "host", Value: "b", Source: SourceDefault}.WithDefault(value.Env("test"))
+	assert.Equal(t, EnvName("test"), entry.DefaultEnv)
+
+	entry = ConfigEntry{Name: "host", Value: "c", Source: SourceArg}.WithDefault(value)
+	assert.Empty(t, entry.DefaultEnv)
+
+	buf := new(bytes.Buffer)
+
+	dump := &ConfigDump{Env: "dev", Flags: []ConfigEntry{{Name: "host", Value: "a", Source: SourceDefault, DefaultEnv: "prod"}}}
+	assert.NoError(t, dump.Write(buf, PrintFormatText))
+	assert.Contains(t, buf.String(), "default (prod)")
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SliceValue implements cli.Generic and pflag.Value for comma separated or repeated values,
+// the first parsed value replaces defaults.
+type SliceValue[T any] struct {
+	value []T
+	typ   string
+	parse func(string) (T, error)
+	set   bool
+}
+
+func NewSliceValue[T any](value []T, typ string, parse func(string) (T, error)) *SliceValue[T] {
+	return &SliceValue[T]{value: value, typ: typ, parse: parse}
+}
+
+func NewUintSliceValue(value []uint) *SliceValue[uint] {
+	return NewSliceValue(value, "uintSlice", func(s string) (uint, error) {
+		u, err := strconv.ParseUint(s, 0, strconv.IntSize)
+
+		return uint(u), err
+	})
+}
+
+func NewUint64SliceValue(value []uint64) *SliceValue[uint64] {
+	return NewSliceValue(value, "uint64Slice", func(s string) (uint64, error) {
+		return strconv.ParseUint(s, 0, 64)
+	})
+}
+
+func NewBoolSliceValue(value []bool) *SliceValue[bool] {
+	return NewSliceValue(value, "boolSlice", strconv.ParseBool)
+}
+
+func NewDurationSliceValue(value []time.Duration) *SliceValue[time.Duration] {
+	return NewSliceValue(value, "durationSlice", time.ParseDuration)
+}
+
+// NewEnumSliceValue returns the value of enum slice flag which accepts only given variants.
+func NewEnumSliceValue[T ~string](value []T, variants ...T) *SliceValue[T] {
+	return NewSliceValue(value, "enumSlice", func(s string) (T, error) {
+		for _, variant := range variants {
+			if s == string(variant) {
+				return variant, nil
+			}
+		}
+
+		return "", errors.Errorf("invalid value %q, allowed values: %s", s, joinList(variants))
+	})
+}
+
+func (v *SliceValue[T]) Set(s string) error {
+	var values []T
+
+	for _, part := range splitList(s) {
+		value, err := v.parse(part)
+		if err != nil {
+			return err
+		}
+
+		values = append(values, value)
+	}
+
+	if !v.set {
+		// the first value replaces defaults
+		v.value, v.set = nil, true
+	}
+
+	v.value = append(v.value, values...)
+
+	return nil
+}
+
+// reset makes the next parsed value replace values parsed before, see ArgsOverrideEnv.
+func (v *SliceValue[T]) reset() {
+	v.set = false
+}
+
+func (v *SliceValue[T]) String() string {
+	return joinList(v.value)
+}
+
+func (v *SliceValue[T]) Get() interface{} {
+	return v.value
+}
+
+func (v *SliceValue[T]) Type() string {
+	return v.typ
+}
+
+// splitList splits comma separated list of values.
+func splitList(s string) []string {
+	parts := strings.Split(s, ",")
+
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+
+	return parts
+}
+
+func toStrings[T any](values []T) []string {
+	parts := make([]string, len(values))
+
+	for i, v := range values {
+		parts[i] = fmt.Sprint(v)
+	}
+
+	return parts
+}
+
+func joinList[T any](values []T) string {
+	return strings.Join(toStrings(values), ",")
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+func TestSliceValue_Set(t *testing.T) {
+	d := NewDurationSliceValue([]time.Duration{time.Second})
+	assert.Equal(t, "1s", d.String())
+
+	assert.NoError(t, d.Set("100ms, 5s"))
+	assert.NoError(t, d.Set("1m"))
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 5 * time.Second, time.Minute}, d.Get())
+	assert.Error(t, d.Set("5"))
+
+	b := NewBoolSliceValue(nil)
+	assert.NoError(t, b.Set("true,false"))
+	assert.Equal(t, []bool{true, false}, b.Get())
+	assert.Error(t, b.Set("yes"))
+}
+
+func TestNewEnumSliceValue(t *testing.T) {
+	type feature string
+
+	v := NewEnumSliceValue([]feature{"search"}, "search", "export")
+	assert.Equal(t, "enumSlice", v.Type())
+
+	assert.NoError(t, v.Set("export,search"))
+	assert.Equal(t, []feature{"export", "search"}, v.Get())
+	assert.EqualError(t, v.Set("beta"), `invalid value "beta", allowed values: search,export`)
+}
+
+func TestArgsOverrideEnv(t *testing.T) {
+	t.Setenv("TEST_PORTS", "80,443")
+
+	run := func(args ...string) []uint {
+		var got interface{}
+
+		app := &cli.App{
+			Flags: []cli.Flag{
+				ArgsOverrideEnv(&cli.GenericFlag{
+					Name:    "ports",
+					EnvVars: []string{"TEST_PORTS"},
+					Value:   NewUintSliceValue(nil),
+				}),
+			},
+			Action: func(ctx *cli.Context) error {
+				got = ctx.Generic("ports").(interface{ Get() interface{} }).Get()
+				return nil
+			},
+		}
+
+		assert.NoError(t, app.Run(append([]string{"app"}, args...)))
+
+		return got.([]uint)
+	}
+
+	assert.Equal(t, []uint{80, 443}, run())
+	assert.Equal(t, []uint{8080, 9090}, run("--ports=8080", "--ports=9090"))
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// TextUnmarshaler is the constraint of custom flag types, the pointer to the type
+// must implement encoding.TextUnmarshaler.
+type TextUnmarshaler[T any] interface {
+	*T
+	encoding.TextUnmarshaler
+}
+
+// TextValue implements cli.Generic and pflag.Value for custom flag types, see TextUnmarshaler.
+type TextValue[T any, PT TextUnmarshaler[T]] struct {
+	value T
+}
+
+func NewTextValue[T any, PT TextUnmarshaler[T]](value T) *TextValue[T, PT] {
+	return &TextValue[T, PT]{value: value}
+}
+
+func (v *TextValue[T, PT]) Set(s string) error {
+	var value T
+
+	err := PT(&value).UnmarshalText([]byte(s))
+	if err != nil {
+		return errors.Wrapf(err, "invalid %T value %q", value, s)
+	}
+
+	v.value = value
+
+	return nil
+}
+
+func (v *TextValue[T, PT]) String() string {
+	// MarshalText or String may be declared with pointer receiver
+	for _, value := range []interface{}{v.value, &v.value} {
+		switch t := value.(type) {
+		case encoding.TextMarshaler:
+			if text, err := t.MarshalText(); err == nil {
+				return string(text)
+			}
+		case fmt.Stringer:
+			return t.String()
+		}
+	}
+
+	return fmt.Sprint(v.value)
+}
+
+func (v *TextValue[T, PT]) Get() interface{} {
+	return v.value
+}
+
+func (v *TextValue[T, PT]) Type() string {
+	return fmt.Sprintf("%T", v.value)
+}
+
+// MustParseText returns the value of custom flag type parsed by UnmarshalText, it panics on error.
+func MustParseText[T any, PT TextUnmarshaler[T]](s string) T {
+	var value T
+
+	err := PT(&value).UnmarshalText([]byte(s))
+	if err != nil {
+		panic(any(errors.Wrapf(err, "invalid %T value %q", value, s)))
+	}
+
+	return value
+}
+
+// ValueOf returns the value of type T held by v, or zero value if it's not set.
+// Values of urfave/cli slices, e.g. *cli.StringSlice, are unwrapped by their Value method.
+func ValueOf[T any](v *Value) T {
+	switch value := v.get().(type) {
+	case T:
+		return value
+	case interface{ Value() T }:
+		return value.Value()
+	default:
+		var zero T
+
+		return zero
+	}
+}
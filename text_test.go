@@ -0,0 +1,30 @@
+package config
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextValue_Set(t *testing.T) {
+	v := NewTextValue(MustParseText[slog.Level]("warn"))
+	assert.Equal(t, "WARN", v.String())
+
+	assert.NoError(t, v.Set("debug"))
+	assert.Equal(t, slog.LevelDebug, v.Get())
+	assert.Error(t, v.Set("verbose"))
+
+	value := NewValue("test").Set("test", nil)
+	assert.Equal(t, slog.LevelInfo, ValueOf[slog.Level](value))
+
+	value.SetGeneric("test", v)
+	assert.Equal(t, slog.LevelDebug, ValueOf[slog.Level](value))
+
+	value.SetStringSlice("test", "a", "b")
+	assert.Equal(t, []string{"a", "b"}, ValueOf[[]string](value))
+
+	assert.Panics(t, func() {
+		MustParseText[slog.Level]("verbose")
+	})
+}
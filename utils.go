@@ -1,37 +1,28 @@
 package config
 
-import (
-	"fmt"
-	"os"
+type EnvName string
 
-	"github.com/pkg/errors"
-)
-
-func toInt64(v interface{}) (int64, error) {
-	switch i := v.(type) {
-	case int:
-		return int64(i), nil
-	case int64:
-		return i, nil
-	default:
-		return 0, errors.Errorf("unsupported int type %T[%v]", v, v)
-	}
+func (en EnvName) String() string {
+	return string(en)
 }
 
+// GetEnvName works like ResolveEnvName but panics on error.
 func GetEnvName(envKey string, environments ...EnvName) EnvName {
-	if len(environments) == 0 {
-		panic(any("required environments"))
+	env, err := ResolveEnvName(envKey, environments...)
+	if err != nil {
+		panic(any(err.Error()))
 	}
 
-	if env := os.Getenv(envKey); env != "" {
-		for _, environment := range environments {
-			if env == environment.String() {
-				return environment
-			}
-		}
+	return env
+}
 
-		panic(any(fmt.Sprintf("invalid environment %q", env)))
+// ResolveEnvName returns the environment named by envKey variable,
+// the first of environments is used if variable is empty.
+func ResolveEnvName(envKey string, environments ...EnvName) (EnvName, error) {
+	resolver := &EnvResolver{
+		Key:  envKey,
+		Envs: environments,
 	}
 
-	return environments[0]
+	return resolver.Resolve()
 }
@@ -20,3 +20,19 @@ func TestGetEnvName(t *testing.T) {
 	env = GetEnvName("TEST_ENV", "one", "two", "three")
 	assert.Equal(t, EnvName("three"), env)
 }
+
+func TestResolveEnvName(t *testing.T) {
+	env, err := ResolveEnvName("TEST_RESOLVE_ENV", "one", "two")
+	assert.NoError(t, err)
+	assert.Equal(t, EnvName("one"), env)
+
+	_, err = ResolveEnvName("TEST_RESOLVE_ENV")
+	assert.Error(t, err)
+
+	err = os.Setenv("TEST_RESOLVE_ENV", "three")
+	assert.NoError(t, err)
+
+	env, err = ResolveEnvName("TEST_RESOLVE_ENV", "one", "two")
+	assert.Error(t, err)
+	assert.Equal(t, EnvName("one"), env)
+}
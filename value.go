@@ -1,8 +1,13 @@
 package config
 
 import (
+	"maps"
+	"net/netip"
+	"net/url"
+	"slices"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
 )
 
@@ -13,6 +18,8 @@ type Value struct {
 	raw map[EnvName]interface{}
 	// list of environments.
 	envs []EnvName
+	// err is the first error of values which cannot be parsed.
+	err error
 }
 
 func (v *Value) setEnv(env EnvName) {
@@ -70,6 +77,71 @@ func (v *Value) SetFloat64Slice(env EnvName, values ...float64) *Value {
 	return v
 }
 
+func (v *Value) SetBoolSlice(env EnvName, values ...bool) *Value {
+	v.setEnv(env)
+	v.raw[env] = values
+
+	return v
+}
+
+func (v *Value) SetDurationSlice(env EnvName, values ...time.Duration) *Value {
+	v.setEnv(env)
+	v.raw[env] = values
+
+	return v
+}
+
+func (v *Value) SetIPSlice(env EnvName, values ...netip.Addr) *Value {
+	v.setEnv(env)
+	v.raw[env] = values
+
+	return v
+}
+
+func (v *Value) SetCIDRSlice(env EnvName, values ...netip.Prefix) *Value {
+	v.setEnv(env)
+	v.raw[env] = values
+
+	return v
+}
+
+func (v *Value) SetStringMap(env EnvName, value map[string]string) *Value {
+	v.setEnv(env)
+	v.raw[env] = value
+
+	return v
+}
+
+func (v *Value) SetIntMap(env EnvName, value map[string]int) *Value {
+	v.setEnv(env)
+	v.raw[env] = value
+
+	return v
+}
+
+func (v *Value) SetInt64Map(env EnvName, value map[string]int64) *Value {
+	v.setEnv(env)
+	v.raw[env] = value
+
+	return v
+}
+
+func (v *Value) SetFloat64Map(env EnvName, value map[string]float64) *Value {
+	v.setEnv(env)
+	v.raw[env] = value
+
+	return v
+}
+
+// SetGeneric sets the value held by flag.Getter, e.g. Value of cli.GenericFlag.
+func (v *Value) SetGeneric(env EnvName, value interface{}) *Value {
+	if getter, ok := value.(interface{ Get() interface{} }); ok {
+		value = getter.Get()
+	}
+
+	return v.Set(env, value)
+}
+
 func (v *Value) SetDuration(env EnvName, value time.Duration) *Value {
 	v.setEnv(env)
 	v.raw[env] = value
@@ -77,16 +149,37 @@ func (v *Value) SetDuration(env EnvName, value time.Duration) *Value {
 	return v
 }
 
+func (v *Value) SetTime(env EnvName, value time.Time) *Value {
+	v.setEnv(env)
+	v.raw[env] = value
+
+	return v
+}
+
+// SetTimestamp sets value parsed as RFC3339 timestamp, the value which cannot be parsed
+// isn't set and its error is returned by Err.
 func (v *Value) SetTimestamp(env EnvName, value string) *Value {
-	ts, err := time.Parse(time.RFC3339, value)
+	return v.SetTimestampIn(env, value, time.RFC3339, time.UTC)
+}
+
+// SetTimestampIn sets value parsed by the layout in the location like timestamp flag does,
+// the value which cannot be parsed isn't set and its error is returned by Err.
+func (v *Value) SetTimestampIn(env EnvName, value, layout string, loc *time.Location) *Value {
+	ts, err := time.ParseInLocation(layout, value, loc)
 	if err != nil {
-		panic(any(err))
+		if v.err == nil {
+			v.err = errors.Wrapf(err, "invalid timestamp of %s environment", env)
+		}
+
+		return v
 	}
 
-	v.setEnv(env)
-	v.raw[env] = ts
+	return v.SetTime(env, ts)
+}
 
-	return v
+// Err returns the first error of values which were set by SetTimestamp or SetTimestampIn and cannot be parsed.
+func (v *Value) Err() error {
+	return v.err
 }
 
 func (v *Value) String() string {
@@ -143,6 +236,141 @@ func (v *Value) Uint64() uint64 {
 	return value.(uint64)
 }
 
+func (v *Value) Int32() int32 {
+	value := v.get()
+	if value == nil {
+		return 0
+	}
+
+	return value.(int32)
+}
+
+func (v *Value) Uint32() uint32 {
+	value := v.get()
+	if value == nil {
+		return 0
+	}
+
+	return value.(uint32)
+}
+
+func (v *Value) Float32() float32 {
+	value := v.get()
+	if value == nil {
+		return 0
+	}
+
+	return value.(float32)
+}
+
+func (v *Value) Bytes() uint64 {
+	value := v.get()
+	if value == nil {
+		return 0
+	}
+
+	return value.(uint64)
+}
+
+func (v *Value) URL() *url.URL {
+	value := v.get()
+	if value == nil {
+		return nil
+	}
+
+	return value.(*url.URL)
+}
+
+func (v *Value) HostPort() string {
+	value := v.get()
+	if value == nil {
+		return ""
+	}
+
+	return value.(string)
+}
+
+func (v *Value) IP() netip.Addr {
+	value := v.get()
+	if value == nil {
+		return netip.Addr{}
+	}
+
+	return value.(netip.Addr)
+}
+
+func (v *Value) BoolSlice() []bool {
+	value := v.get()
+	if value == nil {
+		return nil
+	}
+
+	return value.([]bool)
+}
+
+func (v *Value) DurationSlice() []time.Duration {
+	value := v.get()
+	if value == nil {
+		return nil
+	}
+
+	return value.([]time.Duration)
+}
+
+func (v *Value) IPSlice() []netip.Addr {
+	value := v.get()
+	if value == nil {
+		return nil
+	}
+
+	return value.([]netip.Addr)
+}
+
+func (v *Value) CIDRSlice() []netip.Prefix {
+	value := v.get()
+	if value == nil {
+		return nil
+	}
+
+	return value.([]netip.Prefix)
+}
+
+func (v *Value) StringMap() map[string]string {
+	value := v.get()
+	if value == nil {
+		return nil
+	}
+
+	return value.(map[string]string)
+}
+
+func (v *Value) IntMap() map[string]int {
+	value := v.get()
+	if value == nil {
+		return nil
+	}
+
+	return value.(map[string]int)
+}
+
+func (v *Value) Int64Map() map[string]int64 {
+	value := v.get()
+	if value == nil {
+		return nil
+	}
+
+	return value.(map[string]int64)
+}
+
+func (v *Value) Float64Map() map[string]float64 {
+	value := v.get()
+	if value == nil {
+		return nil
+	}
+
+	return value.(map[string]float64)
+}
+
 func (v *Value) Float64() float64 {
 	value := v.get()
 	if value == nil {
@@ -203,6 +431,11 @@ func (v *Value) UintSlice() *cli.UintSlice {
 		return nil
 	}
 
+	// generic uint slice flags of cli/v2 store plain values
+	if values, ok := value.([]uint); ok {
+		return cli.NewUintSlice(values...)
+	}
+
 	return value.(*cli.UintSlice)
 }
 
@@ -212,6 +445,11 @@ func (v *Value) Uint64Slice() *cli.Uint64Slice {
 		return nil
 	}
 
+	// generic uint slice flags of cli/v2 store plain values
+	if values, ok := value.([]uint64); ok {
+		return cli.NewUint64Slice(values...)
+	}
+
 	return value.(*cli.Uint64Slice)
 }
 
@@ -224,6 +462,69 @@ func (v *Value) Float64Slice() *cli.Float64Slice {
 	return value.(*cli.Float64Slice)
 }
 
+func (v *Value) TimestampValue() time.Time {
+	ts := v.Timestamp()
+	if ts == nil || ts.Value() == nil {
+		return time.Time{}
+	}
+
+	return *ts.Value()
+}
+
+func (v *Value) StringSliceValue() []string {
+	s := v.StringSlice()
+	if s == nil {
+		return nil
+	}
+
+	return s.Value()
+}
+
+func (v *Value) IntSliceValue() []int {
+	s := v.IntSlice()
+	if s == nil {
+		return nil
+	}
+
+	return s.Value()
+}
+
+func (v *Value) Int64SliceValue() []int64 {
+	s := v.Int64Slice()
+	if s == nil {
+		return nil
+	}
+
+	return s.Value()
+}
+
+func (v *Value) UintSliceValue() []uint {
+	s := v.UintSlice()
+	if s == nil {
+		return nil
+	}
+
+	return s.Value()
+}
+
+func (v *Value) Uint64SliceValue() []uint64 {
+	s := v.Uint64Slice()
+	if s == nil {
+		return nil
+	}
+
+	return s.Value()
+}
+
+func (v *Value) Float64SliceValue() []float64 {
+	s := v.Float64Slice()
+	if s == nil {
+		return nil
+	}
+
+	return s.Value()
+}
+
 func (v *Value) get() interface{} {
 	value, ok := v.raw[v.env]
 	if ok {
@@ -237,6 +538,21 @@ func (v *Value) get() interface{} {
 	return v.raw[v.envs[0]]
 }
 
+// DefaultEnv returns the environment which value is used,
+// it's the first environment if the current one has no value.
+func (v *Value) DefaultEnv() EnvName {
+	if _, ok := v.raw[v.env]; ok || len(v.envs) == 0 {
+		return v.env
+	}
+
+	return v.envs[0]
+}
+
+// Clone returns the copy of the value which isn't changed by setters of v.
+func (v *Value) Clone() *Value {
+	return &Value{env: v.env, raw: maps.Clone(v.raw), envs: slices.Clone(v.envs), err: v.err}
+}
+
 func (v *Value) Env(env EnvName) *Value {
 	newValue := *v
 	newValue.env = env
@@ -17,6 +17,17 @@ func TestValue_Int(t *testing.T) {
 	assert.Equal(t, 1, v.Env("unknown").Int())
 }
 
+func TestValue_Clone(t *testing.T) {
+	v := NewValue("test").Set("test", 1)
+	clone := v.Clone()
+
+	v.Set("test", 2).Set("test2", 3)
+
+	assert.Equal(t, 1, clone.Int())
+	assert.Equal(t, 1, clone.Env("test2").Int())
+	assert.Equal(t, 2, v.Int())
+}
+
 func TestValue_Bool(t *testing.T) {
 	v := NewValue("test").
 		Set("test", true).
@@ -62,3 +73,49 @@ func TestValue_Timestamp(t *testing.T) {
 	assert.Equal(t, "2022-05-25T17:15:16Z", v.Env("dev").Timestamp().Value().Format(time.RFC3339))
 	assert.Equal(t, "2023-05-25T17:15:16Z", v.Env("prod").Timestamp().Value().Format(time.RFC3339))
 }
+
+func TestValue_SetTimestampIn(t *testing.T) {
+	berlin := MustLoadLocation("Europe/Berlin")
+
+	v := NewValue("local").SetTimestampIn("local", "2021-05-25", time.DateOnly, berlin)
+	assert.Equal(t, time.Date(2021, 5, 25, 0, 0, 0, 0, berlin), v.TimestampValue())
+
+	assert.NoError(t, v.Err())
+
+	// invalid values are not set and the first error is kept
+	v.SetTimestampIn("prod", "2021-05-25T17:15:16Z", time.DateOnly, berlin).SetTimestamp("prod", "2021-05-25")
+	assert.EqualError(t, v.Err(), `invalid timestamp of prod environment: parsing time "2021-05-25T17:15:16Z": extra text: "T17:15:16Z"`)
+	assert.Equal(t, time.Date(2021, 5, 25, 0, 0, 0, 0, berlin), v.Env("prod").TimestampValue())
+}
+
+func TestTimeValue_Set(t *testing.T) {
+	berlin := MustLoadLocation("Europe/Berlin")
+
+	v := NewTimeValue(time.Time{}, time.DateOnly, berlin)
+	assert.Equal(t, "", v.String())
+
+	assert.NoError(t, v.Set("2024-01-31"))
+	assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, berlin), v.Get())
+	assert.Equal(t, "2024-01-31", v.String())
+	assert.Error(t, v.Set("2024-01-31T00:00:00Z"))
+}
+
+func TestValue_SetGeneric(t *testing.T) {
+	v := NewValue("test").
+		Set("test", uint64(1024)).
+		Set("local", int32(1))
+
+	b := NewBytesValue(v.Bytes())
+	assert.Equal(t, "1KiB", b.String())
+	assert.NoError(t, b.Set("2MiB"))
+
+	v.SetGeneric("test", b)
+	assert.Equal(t, uint64(2<<20), v.Bytes())
+
+	i := NewInt32Value(v.Env("local").Int32())
+	assert.Error(t, i.Set("3000000000"))
+	assert.NoError(t, i.Set("-5"))
+
+	v.SetGeneric("local", i)
+	assert.Equal(t, int32(-5), v.Env("local").Int32())
+}